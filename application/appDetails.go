@@ -0,0 +1,63 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"maas360api/types"
+)
+
+// AppDetails describes a catalog app in more detail than CatalogApp.
+type AppDetails struct {
+	AppName          string               `json:"appName"`
+	AppID            string               `json:"appId"`
+	AppType          AppType              `json:"appType"`
+	Platform         string               `json:"platform"`
+	AppFullVersion   string               `json:"appFullVersion"`
+	AppVersionState  AppVersionState      `json:"appVersionState"`
+	Description      string               `json:"description"`
+	Category         string               `json:"category"`
+	EnterpriseRating types.FlexibleInt    `json:"enterpriseRating"`
+	FileName         string               `json:"fileName"`
+	FileSize         types.FlexibleString `json:"fileSize"`
+	MinimumOSVersion string               `json:"minimumOSVersion"`
+	DeviceType       DeviceType           `json:"deviceType"`
+	Status           string               `json:"status"`
+	InstantUpdate    types.FlexibleBool   `json:"instantUpdate"`
+	RemoveApp        types.FlexibleBool   `json:"removeApp"`
+	UploadDate       types.Date           `json:"uploadDate"`
+	UploadedBy       string               `json:"uploadedBy"`
+	LastUpdated      types.Date           `json:"lastUpdated"`
+	LastUpdatedBy    string               `json:"lastUpdatedBy"`
+	AppIconFullUrl   string               `json:"appIconFullURL"`
+}
+
+// GetAppDetails retrieves the details of a catalog app.
+func GetAppDetails(serviceURL string, billingID string, app CatalogApp, maasToken string) (*AppDetails, error) {
+	params, err := appParams(app)
+	if err != nil {
+		return nil, err
+	}
+	body, err := doAppRequest("GET", serviceURL, billingID, "getAppDetails", params, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("error getting details of app %s: %v", app.AppID, err)
+	}
+
+	var wrapped struct {
+		AppDetails *AppDetails `json:"appDetails"`
+	}
+	if err := json.Unmarshal(body, &wrapped); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+	if wrapped.AppDetails != nil {
+		return wrapped.AppDetails, nil
+	}
+	var details AppDetails
+	if err := json.Unmarshal(body, &details); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+	if details.AppID == "" {
+		return nil, fmt.Errorf("app %s not found", app.AppID)
+	}
+	return &details, nil
+}
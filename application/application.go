@@ -1,7 +1,41 @@
 package application
 
 import (
+	"fmt"
+	"net/url"
+
 	httputil "maas360api/internal/http"
+	"maas360api/internal/response"
 )
 
 var client = httputil.GetSharedClient()
+
+// Result is the outcome of an application change accepted by MaaS360.
+type Result struct {
+	AppID       string
+	Status      string // Status returned by MaaS360
+	Description string // Description returned by MaaS360
+}
+
+// decodeResult parses an application change response, which MaaS360 returns either bare or
+// wrapped in an "actionResponse" object. A status other than "Success" is reported as an error.
+func decodeResult(body []byte) (*Result, error) {
+	type resultBody struct {
+		response.Status
+		AppID string `json:"appId"`
+	}
+	result, err := response.Decode[resultBody](body, "actionResponse")
+	if err != nil {
+		return nil, err
+	}
+	return &Result{AppID: result.AppID, Status: result.Status.Status, Description: result.Description}, nil
+}
+
+// doAppRequest sends a request to a application-apis endpoint and returns the response body.
+// Params are sent as a form body for POST requests and as the query string otherwise.
+func doAppRequest(method string, serviceURL string, billingID string, endpoint string, params url.Values, maasToken string) ([]byte, error) {
+	if serviceURL == "" || billingID == "" || maasToken == "" {
+		return nil, fmt.Errorf("serviceURL, billingID, and maasToken must not be empty")
+	}
+	return httputil.DoFormRequest(method, fmt.Sprintf("%s/application-apis/applications/1.0/%s/customer/%s", serviceURL, endpoint, billingID), params, maasToken)
+}
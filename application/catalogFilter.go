@@ -0,0 +1,230 @@
+package application
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// AppType is the kind of a catalog app.
+type AppType int
+
+const (
+	IOSEnterpriseApp     AppType = 1
+	IOSAppStoreApp       AppType = 2
+	AndroidEnterpriseApp AppType = 3
+	AndroidMarketApp     AppType = 4
+	IOSWebClip           AppType = 8
+	MacAppStoreApp       AppType = 10
+	MacEnterpriseApp     AppType = 11
+)
+
+func (t AppType) String() string {
+	switch t {
+	case IOSEnterpriseApp:
+		return "iOS Enterprise Application"
+	case IOSAppStoreApp:
+		return "iOS App Store Application"
+	case AndroidEnterpriseApp:
+		return "Android Enterprise Application"
+	case AndroidMarketApp:
+		return "Android Market Application"
+	case IOSWebClip:
+		return "iOS Web-Clip"
+	case MacAppStoreApp:
+		return "Mac App Store Application"
+	case MacEnterpriseApp:
+		return "Mac Enterprise Application"
+	default:
+		return fmt.Sprintf("AppType(%d)", int(t))
+	}
+}
+
+// Valid reports whether t is one of the app types accepted by MaaS360.
+func (t AppType) Valid() bool {
+	switch t {
+	case IOSEnterpriseApp, IOSAppStoreApp, AndroidEnterpriseApp, AndroidMarketApp, IOSWebClip, MacAppStoreApp, MacEnterpriseApp:
+		return true
+	default:
+		return false
+	}
+}
+
+// DeviceType is the kind of device a catalog app supports.
+type DeviceType int
+
+const (
+	Smartphone          DeviceType = 1
+	Tablet              DeviceType = 2
+	SmartphoneAndTablet DeviceType = 3
+)
+
+func (t DeviceType) String() string {
+	switch t {
+	case Smartphone:
+		return "Smartphone"
+	case Tablet:
+		return "Tablet"
+	case SmartphoneAndTablet:
+		return "Smartphone, Tablet"
+	default:
+		return fmt.Sprintf("DeviceType(%d)", int(t))
+	}
+}
+
+// Valid reports whether t is one of the device types accepted by MaaS360.
+func (t DeviceType) Valid() bool {
+	return t >= Smartphone && t <= SmartphoneAndTablet
+}
+
+// Category is the catalog category of an app.
+type Category string
+
+const (
+	Business         Category = "Business"
+	Education        Category = "Education"
+	Entertainment    Category = "Entertainment"
+	Finance          Category = "Finance"
+	HealthAndFitness Category = "Health & Fitness"
+	Lifestyle        Category = "Lifestyle"
+	Medical          Category = "Medical"
+	Music            Category = "Music"
+	Navigation       Category = "Navigation"
+	News             Category = "News"
+	Photography      Category = "Photography"
+	Productivity     Category = "Productivity"
+	Reference        Category = "Reference"
+	SocialNetworking Category = "Social Networking"
+	Sports           Category = "Sports"
+	TravelAndLocal   Category = "Travel & Local"
+	Utilities        Category = "Utilities"
+	Weather          Category = "Weather"
+)
+
+// Categories lists the catalog categories accepted by MaaS360.
+var Categories = []Category{
+	Business, Education, Entertainment, Finance, HealthAndFitness, Lifestyle, Medical, Music, Navigation,
+	News, Photography, Productivity, Reference, SocialNetworking, Sports, TravelAndLocal, Utilities, Weather,
+}
+
+func (c Category) String() string {
+	return string(c)
+}
+
+// Valid reports whether c is one of the catalog categories accepted by MaaS360.
+func (c Category) Valid() bool {
+	for _, category := range Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// AppStatus is the catalog status of an app.
+type AppStatus string
+
+const (
+	AppActive  AppStatus = "Active"
+	AppDeleted AppStatus = "Deleted"
+)
+
+func (s AppStatus) String() string {
+	return string(s)
+}
+
+// CatalogPageSizes lists the page sizes accepted by the catalog search API.
+var CatalogPageSizes = []int{25, 50, 100, 200, 250}
+
+// CatalogFilter builds the filters for SearchCatalog, validating each value as it is set.
+// The first invalid value is reported by Build.
+type CatalogFilter struct {
+	filters map[string]string
+	err     error
+}
+
+// NewCatalogFilter returns an empty catalog filter.
+func NewCatalogFilter() *CatalogFilter {
+	return &CatalogFilter{filters: make(map[string]string)}
+}
+
+// AppName matches apps whose name contains name.
+func (f *CatalogFilter) AppName(name string) *CatalogFilter {
+	return f.set("appName", name, name != "", "appName must not be empty")
+}
+
+// AppID matches apps whose ID contains appID.
+func (f *CatalogFilter) AppID(appID string) *CatalogFilter {
+	return f.set("appId", appID, appID != "", "appId must not be empty")
+}
+
+// AppType matches apps of the given type.
+func (f *CatalogFilter) AppType(t AppType) *CatalogFilter {
+	return f.set("appType", strconv.Itoa(int(t)), t.Valid(), fmt.Sprintf("unknown app type %d", int(t)))
+}
+
+// EnterpriseRating matches apps with the given rating, from 1 (lowest) to 5 (highest).
+func (f *CatalogFilter) EnterpriseRating(rating int) *CatalogFilter {
+	return f.set("enterpriseRating", strconv.Itoa(rating), rating >= 1 && rating <= 5, fmt.Sprintf("enterpriseRating must be between 1 and 5, got %d", rating))
+}
+
+// Category matches apps in the given category.
+func (f *CatalogFilter) Category(c Category) *CatalogFilter {
+	return f.set("category", string(c), c.Valid(), fmt.Sprintf("unknown category %q", c))
+}
+
+// Status matches active or deleted apps.
+func (f *CatalogFilter) Status(s AppStatus) *CatalogFilter {
+	return f.set("status", string(s), s == AppActive || s == AppDeleted, fmt.Sprintf("unknown status %q", s))
+}
+
+// DeviceType matches apps supporting the given device type.
+func (f *CatalogFilter) DeviceType(t DeviceType) *CatalogFilter {
+	return f.set("deviceType", strconv.Itoa(int(t)), t.Valid(), fmt.Sprintf("unknown device type %d", int(t)))
+}
+
+// InstantUpdate matches apps with instant update enabled or disabled.
+func (f *CatalogFilter) InstantUpdate(enabled bool) *CatalogFilter {
+	value := "0"
+	if enabled {
+		value = "1"
+	}
+	return f.set("instantUpdate", value, true, "")
+}
+
+// Page selects a page of results. Pages are numbered from 1.
+func (f *CatalogFilter) Page(pageNumber int, pageSize int) *CatalogFilter {
+	validSize := false
+	for _, size := range CatalogPageSizes {
+		validSize = validSize || size == pageSize
+	}
+	f.set("pageSize", strconv.Itoa(pageSize), validSize, fmt.Sprintf("pageSize must be one of %v, got %d", CatalogPageSizes, pageSize))
+	return f.set("pageNumber", strconv.Itoa(pageNumber), pageNumber >= 1, fmt.Sprintf("pageNumber must be at least 1, got %d", pageNumber))
+}
+
+// Build returns the filters, or the first invalid value that was set. The catalog search API
+// requires an app ID, so a filter without AppID is an error.
+func (f *CatalogFilter) Build() (map[string]string, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.filters["appId"] == "" {
+		return nil, fmt.Errorf("appId is a required parameter and cannot be empty")
+	}
+	filters := make(map[string]string, len(f.filters))
+	for key, value := range f.filters {
+		filters[key] = value
+	}
+	return filters, nil
+}
+
+func (f *CatalogFilter) set(key string, value string, valid bool, message string) *CatalogFilter {
+	if f.err != nil {
+		return f
+	}
+	if !valid {
+		f.err = fmt.Errorf("%s", message)
+		return f
+	}
+	f.filters[key] = value
+	return f
+}
@@ -0,0 +1,70 @@
+package application
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCatalogFilter(t *testing.T) {
+	filters, err := NewCatalogFilter().
+		AppName("Mail").
+		AppID("com.example").
+		AppType(AndroidEnterpriseApp).
+		Category(Productivity).
+		DeviceType(Tablet).
+		InstantUpdate(true).
+		Page(2, 50).
+		Build()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := map[string]string{
+		"appName":       "Mail",
+		"appId":         "com.example",
+		"appType":       "3",
+		"category":      "Productivity",
+		"deviceType":    "2",
+		"instantUpdate": "1",
+		"pageNumber":    "2",
+		"pageSize":      "50",
+	}
+	if len(filters) != len(expected) {
+		t.Errorf("Expected %v, got %v", expected, filters)
+	}
+	for key, want := range expected {
+		if filters[key] != want {
+			t.Errorf("Expected %s %q, got %q", key, want, filters[key])
+		}
+	}
+
+	invalid := map[string]*CatalogFilter{
+		"app type":  NewCatalogFilter().AppType(5),
+		"rating":    NewCatalogFilter().EnterpriseRating(6),
+		"category":  NewCatalogFilter().Category("Games"),
+		"status":    NewCatalogFilter().Status("Retired"),
+		"page size": NewCatalogFilter().Page(1, 30),
+		"empty":     NewCatalogFilter(),
+		"no app ID": NewCatalogFilter().AppName("Mail"),
+	}
+	for name, filter := range invalid {
+		if _, err := filter.Build(); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestCatalogApp_Enums(t *testing.T) {
+	var app CatalogApp
+	if err := json.Unmarshal([]byte(`{"appId":"com.example.app","appType":11,"deviceType":3,"appVersionState":1}`), &app); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if app.AppType != MacEnterpriseApp || app.AppType.String() != "Mac Enterprise Application" {
+		t.Errorf("Unexpected app type %v", app.AppType)
+	}
+	if app.DeviceType.String() != "Smartphone, Tablet" {
+		t.Errorf("Unexpected device type %v", app.DeviceType)
+	}
+	if !app.IsPrimary() {
+		t.Error("Expected primary version")
+	}
+}
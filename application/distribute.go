@@ -0,0 +1,221 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"maas360api/internal/paging"
+	"maas360api/types"
+)
+
+// TargetType is the kind of devices an app is distributed to.
+type TargetType int
+
+const (
+	TargetAllDevices TargetType = 0
+	TargetGroup      TargetType = 1
+	TargetDevice     TargetType = 2
+)
+
+// Target selects the devices an app is distributed to. Use AllDevices, ToGroup or ToDevice.
+type Target struct {
+	Type TargetType
+	ID   string // Device group ID or MaaS360 device ID, empty for all devices
+}
+
+// AllDevices targets every device.
+func AllDevices() Target {
+	return Target{Type: TargetAllDevices}
+}
+
+// ToGroup targets the devices in a device group.
+func ToGroup(groupID string) Target {
+	return Target{Type: TargetGroup, ID: groupID}
+}
+
+// ToDevice targets a single device.
+func ToDevice(deviceID string) Target {
+	return Target{Type: TargetDevice, ID: deviceID}
+}
+
+func (t Target) params(app CatalogApp) (url.Values, error) {
+	params, err := appParams(app)
+	if err != nil {
+		return nil, err
+	}
+	params.Set("targetDevices", strconv.Itoa(int(t.Type)))
+	switch t.Type {
+	case TargetAllDevices:
+	case TargetGroup:
+		if t.ID == "" {
+			return nil, fmt.Errorf("group target must have a group ID")
+		}
+		params.Set("deviceGroupId", t.ID)
+	case TargetDevice:
+		if t.ID == "" {
+			return nil, fmt.Errorf("device target must have a device ID")
+		}
+		params.Set("deviceId", t.ID)
+	default:
+		return nil, fmt.Errorf("unknown target type %d", t.Type)
+	}
+	return params, nil
+}
+
+// DistributionOptions control how a distributed app is installed.
+type DistributionOptions struct {
+	InstantInstall   bool // Install without waiting for the user to accept
+	SendEmail        bool // Email the users of the targeted devices
+	SendNotification bool // Notify the users on their devices
+}
+
+// DistributeApp distributes a catalog app to the target devices.
+func DistributeApp(serviceURL string, billingID string, app CatalogApp, target Target, opts DistributionOptions, maasToken string) (*Result, error) {
+	params, err := target.params(app)
+	if err != nil {
+		return nil, err
+	}
+	params.Set("instantInstall", yesNo(opts.InstantInstall))
+	params.Set("sendEmail", yesNo(opts.SendEmail))
+	params.Set("sendNotification", yesNo(opts.SendNotification))
+	return postAppChange(serviceURL, billingID, "distributeApp", app.AppID, params, maasToken)
+}
+
+// StopDistribution stops distributing a catalog app to the target devices.
+func StopDistribution(serviceURL string, billingID string, app CatalogApp, target Target, maasToken string) (*Result, error) {
+	params, err := target.params(app)
+	if err != nil {
+		return nil, err
+	}
+	return postAppChange(serviceURL, billingID, "stopAppDistribution", app.AppID, params, maasToken)
+}
+
+// DeviceDistribution is the distribution status of an app on one device.
+type DeviceDistribution struct {
+	DeviceID         types.FlexibleString `json:"maas360DeviceID"`
+	DeviceName       string               `json:"deviceName"`
+	Username         string               `json:"username"`
+	Status           string               `json:"status"`
+	AppVersion       string               `json:"appVersion"`
+	ErrorDescription string               `json:"errorDescription"`
+	LastUpdated      types.Date           `json:"statusUpdateDate"`
+}
+
+type deviceDistributions []DeviceDistribution
+
+func (d *deviceDistributions) UnmarshalJSON(data []byte) error {
+	// Try as array
+	var arr []DeviceDistribution
+	if err := json.Unmarshal(data, &arr); err == nil {
+		*d = arr
+		return nil
+	}
+	// Try as single object
+	var single DeviceDistribution
+	if err := json.Unmarshal(data, &single); err == nil {
+		*d = []DeviceDistribution{single}
+		return nil
+	}
+	return fmt.Errorf("deviceDistributions: cannot unmarshal %s", string(data))
+}
+
+// DistributionStatus summarizes the distribution of an app.
+type DistributionStatus struct {
+	AppID     string
+	Installed int
+	Pending   int
+	Failed    int
+	Other     int
+	Devices   []DeviceDistribution
+}
+
+// MaxDistributionPageSize is the largest page size accepted by the distribution status API.
+const MaxDistributionPageSize = 250
+
+// GetDistributionStatus retrieves the distribution status of a catalog app on every device it
+// was distributed to, with counts of installed, pending and failed installs.
+func GetDistributionStatus(serviceURL string, billingID string, app CatalogApp, maasToken string) (*DistributionStatus, error) {
+	params, err := appParams(app)
+	if err != nil {
+		return nil, err
+	}
+	params.Set("pageSize", strconv.Itoa(MaxDistributionPageSize))
+
+	distributions, err := paging.All(MaxDistributionPageSize, func(pageNumber int) ([]DeviceDistribution, int, error) {
+		params.Set("pageNumber", strconv.Itoa(pageNumber))
+		body, err := doAppRequest("GET", serviceURL, billingID, "getAppDistributionByDevice", params, maasToken)
+		if err != nil {
+			return nil, 0, err
+		}
+		var page struct {
+			AppDistributions struct {
+				Count           int                 `json:"count"`
+				AppDistribution deviceDistributions `json:"appDistribution"`
+			} `json:"appDistributions"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, 0, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+		}
+		return page.AppDistributions.AppDistribution, page.AppDistributions.Count, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	status := &DistributionStatus{AppID: app.AppID, Devices: distributions}
+
+	for _, device := range status.Devices {
+		switch distributionState(device.Status) {
+		case "installed":
+			status.Installed++
+		case "pending":
+			status.Pending++
+		case "failed":
+			status.Failed++
+		default:
+			status.Other++
+		}
+	}
+	return status, nil
+}
+
+// distributionState maps a distribution status reported by MaaS360 to installed, pending or failed.
+func distributionState(status string) string {
+	status = strings.ToLower(status)
+	switch {
+	case strings.Contains(status, "fail"), strings.Contains(status, "error"), strings.Contains(status, "reject"):
+		return "failed"
+	case strings.Contains(status, "pending"), strings.Contains(status, "sent"), strings.Contains(status, "queued"), strings.Contains(status, "progress"):
+		return "pending"
+	case strings.Contains(status, "installed"), strings.Contains(status, "success"):
+		return "installed"
+	default:
+		return ""
+	}
+}
+
+// postAppChange posts params to an application-apis endpoint and decodes the result.
+func postAppChange(serviceURL string, billingID string, endpoint string, appID string, params url.Values, maasToken string) (*Result, error) {
+	body, err := doAppRequest("POST", serviceURL, billingID, endpoint, params, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed for app %s: %v", endpoint, appID, err)
+	}
+	result, err := decodeResult(body)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed for app %s: %v", endpoint, appID, err)
+	}
+	if result.AppID == "" {
+		result.AppID = appID
+	}
+	return result, nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
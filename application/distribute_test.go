@@ -0,0 +1,67 @@
+package application
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDistributeApp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/application-apis/applications/1.0/distributeApp/customer/1234" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Unexpected error parsing form: %v", err)
+		}
+		for key, want := range map[string]string{"appId": "com.example.app", "appType": "3", "targetDevices": "1", "deviceGroupId": "101", "instantInstall": "Yes"} {
+			if got := r.PostForm.Get(key); got != want {
+				t.Errorf("Expected %s %q, got %q", key, want, got)
+			}
+		}
+		w.Write([]byte(`{"actionResponse":{"status":"Success","description":"Distributed"}}`))
+	}))
+	defer server.Close()
+
+	app := CatalogApp{AppID: "com.example.app", AppType: 3}
+	result, err := DistributeApp(server.URL, "1234", app, ToGroup("101"), DistributionOptions{InstantInstall: true}, "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.AppID != "com.example.app" {
+		t.Errorf("Expected app ID com.example.app, got %s", result.AppID)
+	}
+
+	if _, err := DistributeApp(server.URL, "1234", app, ToDevice(""), DistributionOptions{}, "token"); err == nil {
+		t.Error("Expected error for device target without ID")
+	}
+	if _, err := StopDistribution(server.URL, "1234", CatalogApp{AppID: "com.example.app"}, AllDevices(), "token"); err == nil {
+		t.Error("Expected error for app without appType")
+	}
+}
+
+func TestGetDistributionStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/application-apis/applications/1.0/getAppDistributionByDevice/customer/1234" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"appDistributions":{"count":4,"appDistribution":[
+			{"maas360DeviceID":"A","status":"Installed"},
+			{"maas360DeviceID":"B","status":"Pending Install"},
+			{"maas360DeviceID":"C","status":"Install Failed","errorDescription":"Insufficient storage"},
+			{"maas360DeviceID":"D","status":"Installed"}
+		]}}`))
+	}))
+	defer server.Close()
+
+	status, err := GetDistributionStatus(server.URL, "1234", CatalogApp{AppID: "com.example.app", AppType: 1}, "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status.Installed != 2 || status.Pending != 1 || status.Failed != 1 || status.Other != 0 {
+		t.Errorf("Unexpected counts: %+v", status)
+	}
+	if len(status.Devices) != 4 || status.Devices[2].ErrorDescription != "Insufficient storage" {
+		t.Errorf("Unexpected devices: %+v", status.Devices)
+	}
+}
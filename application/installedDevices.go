@@ -0,0 +1,118 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	httputil "maas360api/internal/http"
+	"maas360api/internal/paging"
+	"maas360api/types"
+)
+
+// MaxInstalledDevicesPageSize is the largest page size accepted by the installed devices API.
+const MaxInstalledDevicesPageSize = 250
+
+// InstalledDevice is a device with a given app installed.
+type InstalledDevice struct {
+	DeviceID      types.FlexibleString `json:"maas360DeviceID"`
+	DeviceName    string               `json:"deviceName"`
+	Username      string               `json:"username"`
+	Platform      string               `json:"platformName"`
+	AppVersion    string               `json:"appVersion"`
+	InstalledDate types.Date           `json:"installedDate"`
+}
+
+type installedDevices []InstalledDevice
+
+func (d *installedDevices) UnmarshalJSON(data []byte) error {
+	// Try as array
+	var arr []InstalledDevice
+	if err := json.Unmarshal(data, &arr); err == nil {
+		*d = arr
+		return nil
+	}
+	// Try as single object
+	var single InstalledDevice
+	if err := json.Unmarshal(data, &single); err == nil {
+		*d = []InstalledDevice{single}
+		return nil
+	}
+	return fmt.Errorf("installedDevices: cannot unmarshal %s", string(data))
+}
+
+// ListInstalledDevices returns one page of the devices with an app installed. If version is not
+// empty, only devices with that version are returned. Pages are numbered from 1. The total number
+// of matching devices is returned alongside the page.
+func ListInstalledDevices(serviceURL string, billingID string, appID string, version string, pageNumber int, pageSize int, maasToken string) ([]InstalledDevice, int, error) {
+	if serviceURL == "" || billingID == "" || appID == "" || maasToken == "" {
+		return nil, 0, fmt.Errorf("serviceURL, billingID, appID, and maasToken must not be empty")
+	}
+	if pageNumber < 1 {
+		return nil, 0, fmt.Errorf("pageNumber must be at least 1, got %d", pageNumber)
+	}
+	if pageSize < 1 || pageSize > MaxInstalledDevicesPageSize {
+		return nil, 0, fmt.Errorf("pageSize must be between 1 and %d, got %d", MaxInstalledDevicesPageSize, pageSize)
+	}
+
+	params := url.Values{}
+	params.Set("appID", appID)
+	if version != "" {
+		params.Set("appVersion", version)
+	}
+	params.Set("pageNumber", strconv.Itoa(pageNumber))
+	params.Set("pageSize", strconv.Itoa(pageSize))
+	searchURL := fmt.Sprintf("%s/application-apis/installedApps/1.0/getDevicesWithApp/%s", serviceURL, billingID)
+
+	body, err := httputil.DoFormRequest("GET", searchURL, params, maasToken)
+	if err != nil {
+		return nil, 0, err
+	}
+	var response struct {
+		Devices struct {
+			Count  int              `json:"count"`
+			Device installedDevices `json:"device"`
+		} `json:"devices"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, 0, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+	return response.Devices.Device, response.Devices.Count, nil
+}
+
+// ListAllInstalledDevices pages through the devices with an app installed and returns all of
+// them. If version is not empty, only devices with that version are returned.
+func ListAllInstalledDevices(serviceURL string, billingID string, appID string, version string, maasToken string) ([]InstalledDevice, error) {
+	return paging.All(MaxInstalledDevicesPageSize, func(pageNumber int) ([]InstalledDevice, int, error) {
+		return ListInstalledDevices(serviceURL, billingID, appID, version, pageNumber, MaxInstalledDevicesPageSize, maasToken)
+	})
+}
+
+// VersionCount is the number of devices with a version of an app installed.
+type VersionCount struct {
+	Version string
+	Devices int
+}
+
+// VersionBreakdown counts the devices per installed app version, newest version first.
+func VersionBreakdown(installed []InstalledDevice) []VersionCount {
+	counts := make(map[string]int)
+	for _, device := range installed {
+		counts[device.AppVersion]++
+	}
+	breakdown := make([]VersionCount, 0, len(counts))
+	for version, devices := range counts {
+		breakdown = append(breakdown, VersionCount{Version: version, Devices: devices})
+	}
+	sort.Slice(breakdown, func(i, j int) bool {
+		a, _ := types.ParseOSVersion(breakdown[i].Version)
+		b, _ := types.ParseOSVersion(breakdown[j].Version)
+		if c := a.Compare(b); c != 0 {
+			return c > 0
+		}
+		return breakdown[i].Version > breakdown[j].Version
+	})
+	return breakdown
+}
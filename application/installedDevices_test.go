@@ -0,0 +1,67 @@
+package application
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListAllInstalledDevices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/application-apis/installedApps/1.0/getDevicesWithApp/1234" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("appID"); got != "com.example.app" {
+			t.Errorf("Expected appID com.example.app, got %q", got)
+		}
+		w.Write([]byte(`{"devices":{"count":4,"device":[
+			{"maas360DeviceID":"A","appVersion":"3.2"},
+			{"maas360DeviceID":"B","appVersion":"3.10"},
+			{"maas360DeviceID":"C","appVersion":"3.2"},
+			{"maas360DeviceID":"D","appVersion":"3.9.1"}
+		]}}`))
+	}))
+	defer server.Close()
+
+	installed, err := ListAllInstalledDevices(server.URL, "1234", "com.example.app", "", "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(installed) != 4 {
+		t.Fatalf("Expected 4 devices, got %d", len(installed))
+	}
+
+	expected := []VersionCount{{"3.10", 1}, {"3.9.1", 1}, {"3.2", 2}}
+	breakdown := VersionBreakdown(installed)
+	if len(breakdown) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, breakdown)
+	}
+	for i := range expected {
+		if breakdown[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, breakdown)
+			break
+		}
+	}
+
+	if _, _, err := ListInstalledDevices(server.URL, "1234", "com.example.app", "", 1, 500, "token"); err == nil {
+		t.Error("Expected error for oversized page")
+	}
+}
+
+func TestGetAppDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/application-apis/applications/1.0/getAppDetails/customer/1234" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"appDetails":{"appId":"com.example.app","appName":"Example","appType":3,"enterpriseRating":"4","instantUpdate":"Yes","uploadDate":"2024-02-01"}}`))
+	}))
+	defer server.Close()
+
+	details, err := GetAppDetails(server.URL, "1234", CatalogApp{AppID: "com.example.app", AppType: 3}, "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if details.AppName != "Example" || details.EnterpriseRating.Value != 4 || !details.InstantUpdate.Value || details.UploadDate.Value.IsZero() {
+		t.Errorf("Unexpected details: %+v", details)
+	}
+}
@@ -0,0 +1,132 @@
+package application
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// AppVersionState is the state of one version of a catalog app.
+type AppVersionState int
+
+const (
+	AppVersionPrimary   AppVersionState = 1 // The version distributed to devices
+	AppVersionSecondary AppVersionState = 2 // An additional version kept in the catalog
+)
+
+func (s AppVersionState) String() string {
+	switch s {
+	case AppVersionPrimary:
+		return "Primary"
+	case AppVersionSecondary:
+		return "Secondary"
+	default:
+		return fmt.Sprintf("AppVersionState(%d)", int(s))
+	}
+}
+
+// IsPrimary reports whether the app version is the primary version of its app.
+func (a CatalogApp) IsPrimary() bool {
+	return a.AppVersionState == AppVersionPrimary
+}
+
+// StaleVersions returns the secondary versions of the given apps whose app also has a primary
+// version among them, so that they can be deleted with DeleteAppVersion.
+func StaleVersions(apps []CatalogApp) []CatalogApp {
+	hasPrimary := make(map[string]bool)
+	for _, app := range apps {
+		if app.IsPrimary() {
+			hasPrimary[app.AppID] = true
+		}
+	}
+	var stale []CatalogApp
+	for _, app := range apps {
+		if app.AppVersionState == AppVersionSecondary && hasPrimary[app.AppID] {
+			stale = append(stale, app)
+		}
+	}
+	return stale
+}
+
+// DeleteApp deletes a catalog app with all of its versions.
+func DeleteApp(serviceURL string, billingID string, app CatalogApp, maasToken string) (*Result, error) {
+	params, err := appParams(app)
+	if err != nil {
+		return nil, err
+	}
+	return postAppChange(serviceURL, billingID, "deleteApp", app.AppID, params, maasToken)
+}
+
+// DeleteAppVersion deletes one secondary version of a catalog app. The primary version can only
+// be removed with DeleteApp.
+func DeleteAppVersion(serviceURL string, billingID string, app CatalogApp, maasToken string) (*Result, error) {
+	params, err := appVersionParams(app)
+	if err != nil {
+		return nil, err
+	}
+	if app.IsPrimary() {
+		return nil, fmt.Errorf("version %s is the primary version of app %s", app.AppFullVersion, app.AppID)
+	}
+	return postAppChange(serviceURL, billingID, "deleteAppVersion", app.AppID, params, maasToken)
+}
+
+// MarkAsPrimary makes a version of a catalog app its primary version.
+func MarkAsPrimary(serviceURL string, billingID string, app CatalogApp, maasToken string) (*Result, error) {
+	params, err := appVersionParams(app)
+	if err != nil {
+		return nil, err
+	}
+	return postAppChange(serviceURL, billingID, "markAsPrimary", app.AppID, params, maasToken)
+}
+
+// UpgradeOptions control how a new version of an app is added.
+type UpgradeOptions struct {
+	KeepPreviousVersion bool // Keep the current version as a secondary version
+	InstantUpdate       bool // Push the new version to devices without waiting for the user
+}
+
+// UpgradeApp uploads a new version of an existing enterprise app, reading the binary from r.
+// The binary is streamed as it is read; size is its exact length, or -1 if unknown, as for
+// UploadEnterpriseApp, and progress may be nil.
+func UpgradeApp(serviceURL string, billingID string, app CatalogApp, fileName string, r io.Reader, size int64, opts UpgradeOptions, progress ProgressFunc, maasToken string) (*Result, error) {
+	params, err := appParams(app)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := enterpriseEndpoints[strings.ToLower(filepath.Ext(fileName))]; !ok {
+		return nil, fmt.Errorf("unsupported app file %s, expected an .ipa, .apk or .pkg file", fileName)
+	}
+	params.Set("maintainAsAdditionalVersion", yesNo(opts.KeepPreviousVersion))
+	params.Set("instantUpdate", yesNo(opts.InstantUpdate))
+	return uploadApp(serviceURL, billingID, "upgradeApp", fileName, r, size, params, progress, maasToken)
+}
+
+// appParams returns the parameters identifying an app.
+func appParams(app CatalogApp) (url.Values, error) {
+	if app.AppID == "" {
+		return nil, fmt.Errorf("app must have an appId")
+	}
+	if !app.AppType.Valid() {
+		return nil, fmt.Errorf("app %s has unknown app type %d", app.AppID, int(app.AppType))
+	}
+	params := url.Values{}
+	params.Set("appId", app.AppID)
+	params.Set("appType", strconv.Itoa(int(app.AppType)))
+	return params, nil
+}
+
+// appVersionParams returns the parameters identifying a version of an app.
+func appVersionParams(app CatalogApp) (url.Values, error) {
+	params, err := appParams(app)
+	if err != nil {
+		return nil, err
+	}
+	if app.AppFullVersion == "" {
+		return nil, fmt.Errorf("app %s has no appFullVersion", app.AppID)
+	}
+	params.Set("appVersion", app.AppFullVersion)
+	return params, nil
+}
@@ -0,0 +1,75 @@
+package application
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestStaleVersions(t *testing.T) {
+	apps := []CatalogApp{
+		{AppID: "com.example.a", AppFullVersion: "3.3", AppVersionState: AppVersionPrimary},
+		{AppID: "com.example.a", AppFullVersion: "3.2", AppVersionState: AppVersionSecondary},
+		{AppID: "com.example.b", AppFullVersion: "1.0", AppVersionState: AppVersionSecondary},
+	}
+
+	stale := StaleVersions(apps)
+	if len(stale) != 1 || stale[0].AppFullVersion != "3.2" {
+		t.Errorf("Expected only version 3.2 of com.example.a, got %+v", stale)
+	}
+	if got := AppVersionSecondary.String(); got != "Secondary" {
+		t.Errorf("Expected Secondary, got %s", got)
+	}
+}
+
+func TestAppLifecycle(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if strings.HasSuffix(r.URL.Path, "/upgradeApp/customer/1234") {
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("Expected multipart body: %v", err)
+			}
+			if got := r.FormValue("maintainAsAdditionalVersion"); got != "Yes" {
+				t.Errorf("Expected maintainAsAdditionalVersion Yes, got %q", got)
+			}
+		} else {
+			if err := r.ParseForm(); err != nil {
+				t.Fatalf("Unexpected error parsing form: %v", err)
+			}
+			if got := r.PostForm.Get("appVersion"); got != "3.2" {
+				t.Errorf("Expected appVersion 3.2, got %q", got)
+			}
+		}
+		w.Write([]byte(`{"actionResponse":{"status":"Success"}}`))
+	}))
+	defer server.Close()
+
+	secondary := CatalogApp{AppID: "com.example.a", AppType: 1, AppFullVersion: "3.2", AppVersionState: AppVersionSecondary}
+	if _, err := MarkAsPrimary(server.URL, "1234", secondary, "token"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := DeleteAppVersion(server.URL, "1234", secondary, "token"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	opts := UpgradeOptions{KeepPreviousVersion: true}
+	if _, err := UpgradeApp(server.URL, "1234", secondary, "a.ipa", strings.NewReader("binary"), 6, opts, nil, "token"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"/application-apis/applications/1.0/markAsPrimary/customer/1234",
+		"/application-apis/applications/1.0/deleteAppVersion/customer/1234",
+		"/application-apis/applications/1.0/upgradeApp/customer/1234",
+	}
+	if strings.Join(paths, " ") != strings.Join(expected, " ") {
+		t.Errorf("Expected requests %v, got %v", expected, paths)
+	}
+
+	primary := secondary
+	primary.AppVersionState = AppVersionPrimary
+	if _, err := DeleteAppVersion(server.URL, "1234", primary, "token"); err == nil {
+		t.Error("Expected error deleting the primary version")
+	}
+}
@@ -9,32 +9,33 @@ import (
 	"net/url"
 
 	"maas360api/internal/constants"
+	"maas360api/types"
 )
 
 type CatalogApp struct {
-	AppIconUrl       string `json:"appIconURL"`
-	AppName          string `json:"appName"`
-	AppID            string `json:"appId"`
-	EnterpriseRating string `json:"enterpriseRating"`
-	FileName         string `json:"fileName"`
-	Platform         string `json:"platform"`
-	AppType          int    `json:"appType"`
-	AppIconFullUrl   string `json:"appIconFullURL"`
-	AppFullVersion   string `json:"appFullVersion"`
-	AppVersionState  int    `json:"appVersionState"`
-	Category         string `json:"category"`
-	FileSize         string `json:"fileSize"`
-	Status           string `json:"status"`
-	DeviceType       int    `json:"deviceType"`
-	UploadDate       string `json:"uploadDate"`
-	UploadedBy       string `json:"uploadedBy"`
-	LastUpdated      string `json:"lastUpdated"`
-	InstantUpdate    int    `json:"instantUpdate"`
-	LastUpdatedBy    string `json:"lastUpdatedBy"`
-	GroupName        string `json:"groupName"`
-	GroupId          int    `json:"groupId"`
-	SsId             int    `json:"ssId"`
-	VppCodes         string `json:"vppCodes"`
+	AppIconUrl       string               `json:"appIconURL"`
+	AppName          string               `json:"appName"`
+	AppID            string               `json:"appId"`
+	EnterpriseRating string               `json:"enterpriseRating"`
+	FileName         string               `json:"fileName"`
+	Platform         string               `json:"platform"`
+	AppType          AppType              `json:"appType"`
+	AppIconFullUrl   string               `json:"appIconFullURL"`
+	AppFullVersion   string               `json:"appFullVersion"`
+	AppVersionState  AppVersionState      `json:"appVersionState"`
+	Category         string               `json:"category"`
+	FileSize         types.FlexibleString `json:"fileSize"`
+	Status           string               `json:"status"`
+	DeviceType       DeviceType           `json:"deviceType"`
+	UploadDate       string               `json:"uploadDate"`
+	UploadedBy       string               `json:"uploadedBy"`
+	LastUpdated      string               `json:"lastUpdated"`
+	InstantUpdate    types.FlexibleBool   `json:"instantUpdate"`
+	LastUpdatedBy    string               `json:"lastUpdatedBy"`
+	GroupName        string               `json:"groupName"`
+	GroupId          types.FlexibleInt    `json:"groupId"`
+	SsId             types.FlexibleInt    `json:"ssId"`
+	VppCodes         string               `json:"vppCodes"`
 }
 
 type CatalogApps struct {
@@ -49,6 +50,7 @@ type CatalogAppsResponse struct {
 }
 
 // SearchCatalog retrieves applications from the MaaS360 catalog based on the provided filters.
+// NewCatalogFilter builds validated filters.
 func SearchCatalog(serviceURL string, billingID string, filters map[string]string, maasToken string) ([]CatalogApp, error) {
 	// Parameters:
 	// pageSize: Limit number of applications returned at one time. Allowed page sizes: 25, 50, 100, 200, 250. Default value: 25.
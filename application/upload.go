@@ -0,0 +1,246 @@
+package application
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	httputil "maas360api/internal/http"
+)
+
+var uploadClient = httputil.NewUploadClient()
+
+// AppMetadata describes how an app is presented and managed in the catalog.
+type AppMetadata struct {
+	Category           Category
+	Description        string
+	EnterpriseRating   int  // 1 (lowest) to 5 (highest), 0 to leave unset
+	InstantUpdate      bool // Push new versions to devices without waiting for the user
+	RemoveOnMDMRemoval bool // Remove the app when the device's MDM control is removed
+}
+
+// Validate checks the metadata values accepted by MaaS360.
+func (m AppMetadata) Validate() error {
+	if m.Category != "" && !m.Category.Valid() {
+		return fmt.Errorf("unknown category %q", m.Category)
+	}
+	if m.EnterpriseRating < 0 || m.EnterpriseRating > 5 {
+		return fmt.Errorf("enterpriseRating must be between 1 and 5, got %d", m.EnterpriseRating)
+	}
+	return nil
+}
+
+func (m AppMetadata) params() url.Values {
+	params := url.Values{}
+	if m.Category != "" {
+		params.Set("category", string(m.Category))
+	}
+	if m.Description != "" {
+		params.Set("description", m.Description)
+	}
+	if m.EnterpriseRating != 0 {
+		params.Set("enterpriseRating", strconv.Itoa(m.EnterpriseRating))
+	}
+	params.Set("instantUpdate", yesNo(m.InstantUpdate))
+	params.Set("removeApp", yesNo(m.RemoveOnMDMRemoval))
+	return params
+}
+
+// ProgressFunc is called as an upload proceeds with the number of bytes sent so far and the
+// total size, or -1 if the size is unknown.
+type ProgressFunc func(sent int64, total int64)
+
+// enterpriseEndpoints maps an app file extension to the endpoint that uploads it.
+var enterpriseEndpoints = map[string]string{
+	".ipa": "addiOSEnterpriseApp",
+	".apk": "addAndroidEnterpriseApp",
+	".pkg": "addMacEnterpriseApp",
+}
+
+// UploadEnterpriseAppFile uploads an iOS (.ipa), Android (.apk) or Mac (.pkg) enterprise app
+// from a file to the catalog.
+func UploadEnterpriseAppFile(serviceURL string, billingID string, path string, meta AppMetadata, progress ProgressFunc, maasToken string) (*Result, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening app file: %v", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("error reading app file: %v", err)
+	}
+	return UploadEnterpriseApp(serviceURL, billingID, filepath.Base(path), file, info.Size(), meta, progress, maasToken)
+}
+
+// UploadEnterpriseApp uploads an iOS (.ipa), Android (.apk) or Mac (.pkg) enterprise app to the
+// catalog, reading the binary from r. The file name selects the platform. The binary is streamed
+// as it is read, so it is never held in memory as a whole. Size is the exact length of the binary,
+// used to send the request with a Content-Length and to report progress; if it is -1 the request
+// is sent with chunked encoding. Progress may be nil.
+func UploadEnterpriseApp(serviceURL string, billingID string, fileName string, r io.Reader, size int64, meta AppMetadata, progress ProgressFunc, maasToken string) (*Result, error) {
+	endpoint, ok := enterpriseEndpoints[strings.ToLower(filepath.Ext(fileName))]
+	if !ok {
+		return nil, fmt.Errorf("unsupported app file %s, expected an .ipa, .apk or .pkg file", fileName)
+	}
+	if err := meta.Validate(); err != nil {
+		return nil, err
+	}
+	return uploadApp(serviceURL, billingID, endpoint, fileName, r, size, meta.params(), progress, maasToken)
+}
+
+// uploadApp streams a multipart request with the form fields in params followed by the binary
+// read from r in an "appSource" part.
+func uploadApp(serviceURL string, billingID string, endpoint string, fileName string, r io.Reader, size int64, params url.Values, progress ProgressFunc, maasToken string) (*Result, error) {
+	if serviceURL == "" || billingID == "" || maasToken == "" {
+		return nil, fmt.Errorf("serviceURL, billingID, and maasToken must not be empty")
+	}
+	if progress != nil {
+		r = &progressReader{r: r, total: size, progress: progress}
+	}
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	writer := multipart.NewWriter(pw)
+
+	var contentLength int64
+	if size >= 0 {
+		overhead, err := uploadOverhead(writer.Boundary(), fileName, params)
+		if err != nil {
+			return nil, fmt.Errorf("error preparing upload of %s: %v", fileName, err)
+		}
+		contentLength = overhead + size
+	}
+
+	go func() {
+		pw.CloseWithError(writeUpload(writer, fileName, r, params))
+	}()
+
+	uploadURL := fmt.Sprintf("%s/application-apis/applications/1.0/%s/customer/%s", serviceURL, endpoint, billingID)
+	resp, err := httputil.DoMaaSRequest(httputil.RequestOptions{
+		Method:        "POST",
+		URL:           uploadURL,
+		Body:          pr,
+		ContentType:   writer.FormDataContentType(),
+		ContentLength: contentLength,
+		MaaSToken:     maasToken,
+		Client:        uploadClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error uploading %s: %v", fileName, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %v", err)
+	}
+	result, err := decodeResult(body)
+	if err != nil {
+		return nil, fmt.Errorf("error uploading %s: %v", fileName, err)
+	}
+	return result, nil
+}
+
+// writeUpload writes the multipart body of an upload.
+func writeUpload(writer *multipart.Writer, fileName string, r io.Reader, params url.Values) error {
+	part, err := writeUploadFields(writer, fileName, params)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return fmt.Errorf("error reading app binary: %v", err)
+	}
+	return writer.Close()
+}
+
+// writeUploadFields writes the form fields, in a fixed order, and the header of the "appSource"
+// part, and returns the writer for the binary.
+func writeUploadFields(writer *multipart.Writer, fileName string, params url.Values) (io.Writer, error) {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range params[key] {
+			if err := writer.WriteField(key, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return writer.CreateFormFile("appSource", fileName)
+}
+
+// uploadOverhead returns the number of bytes an upload's multipart body adds to the binary,
+// by writing everything but the binary with the same boundary.
+func uploadOverhead(boundary string, fileName string, params url.Values) (int64, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return 0, err
+	}
+	if _, err := writeUploadFields(writer, fileName, params); err != nil {
+		return 0, err
+	}
+	if err := writer.Close(); err != nil {
+		return 0, err
+	}
+	return int64(buf.Len()), nil
+}
+
+// progressReader reports the bytes read through it.
+type progressReader struct {
+	r        io.Reader
+	sent     int64
+	total    int64
+	progress ProgressFunc
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.progress(p.sent, p.total)
+	}
+	return n, err
+}
+
+// Store is an app store that catalog apps can be added from.
+type Store int
+
+const (
+	AppStore    Store = iota // Apple App Store, identified by the iTunes app ID
+	GooglePlay               // Google Play, identified by the package name
+	MacAppStore              // Mac App Store, identified by the iTunes app ID
+)
+
+var storeEndpoints = map[Store]string{
+	AppStore:    "addITunesApp",
+	GooglePlay:  "addGooglePlayApp",
+	MacAppStore: "addMacAppStoreApp",
+}
+
+// AddStoreApp adds an app from a public app store to the catalog.
+func AddStoreApp(serviceURL string, billingID string, store Store, storeID string, meta AppMetadata, maasToken string) (*Result, error) {
+	endpoint, ok := storeEndpoints[store]
+	if !ok {
+		return nil, fmt.Errorf("unknown store %d", store)
+	}
+	if storeID == "" {
+		return nil, fmt.Errorf("storeID must not be empty")
+	}
+	if err := meta.Validate(); err != nil {
+		return nil, err
+	}
+	params := meta.params()
+	params.Set("appSourceId", storeID)
+	return postAppChange(serviceURL, billingID, endpoint, storeID, params, maasToken)
+}
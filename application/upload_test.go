@@ -0,0 +1,108 @@
+package application
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUploadEnterpriseApp(t *testing.T) {
+	binary := strings.Repeat("x", 1<<20)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/application-apis/applications/1.0/addAndroidEnterpriseApp/customer/1234" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if r.ContentLength <= int64(len(binary)) || len(r.TransferEncoding) != 0 {
+			t.Errorf("Expected a Content-Length covering the binary and no chunked encoding, got %d %v", r.ContentLength, r.TransferEncoding)
+		}
+		reader, err := r.MultipartReader()
+		if err != nil {
+			t.Fatalf("Expected multipart body: %v", err)
+		}
+		fields := map[string]string{}
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error reading part: %v", err)
+			}
+			data, _ := io.ReadAll(part)
+			if part.FormName() == "appSource" {
+				if part.FileName() != "app.apk" || len(data) != len(binary) {
+					t.Errorf("Unexpected app part %s with %d bytes", part.FileName(), len(data))
+				}
+				continue
+			}
+			fields[part.FormName()] = string(data)
+		}
+		if fields["category"] != "Productivity" || fields["enterpriseRating"] != "4" || fields["instantUpdate"] != "Yes" {
+			t.Errorf("Unexpected fields: %v", fields)
+		}
+		w.Write([]byte(`{"actionResponse":{"status":"Success","appId":"com.example.app"}}`))
+	}))
+	defer server.Close()
+
+	var sent, total int64
+	progress := func(s int64, t int64) { sent, total = s, t }
+	meta := AppMetadata{Category: "Productivity", EnterpriseRating: 4, InstantUpdate: true}
+	result, err := UploadEnterpriseApp(server.URL, "1234", "app.apk", strings.NewReader(binary), int64(len(binary)), meta, progress, "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.AppID != "com.example.app" {
+		t.Errorf("Expected app ID com.example.app, got %s", result.AppID)
+	}
+	if sent != int64(len(binary)) || total != int64(len(binary)) {
+		t.Errorf("Expected progress %d/%d, got %d/%d", len(binary), len(binary), sent, total)
+	}
+
+	if _, err := UploadEnterpriseApp(server.URL, "1234", "app.zip", strings.NewReader(""), 0, meta, nil, "token"); err == nil {
+		t.Error("Expected error for unsupported file type")
+	}
+	if _, err := UploadEnterpriseApp(server.URL, "1234", "app.ipa", strings.NewReader(""), 0, AppMetadata{EnterpriseRating: 6}, nil, "token"); err == nil {
+		t.Error("Expected error for invalid rating")
+	}
+}
+
+func TestUploadEnterpriseApp_ContentLength(t *testing.T) {
+	binary := strings.Repeat("x", 4096)
+	meta := AppMetadata{Category: "Productivity", Description: "Field app", EnterpriseRating: 4}
+
+	tests := []struct {
+		name        string
+		size        int64
+		wantChunked bool
+	}{
+		{name: "known size", size: int64(len(binary))},
+		{name: "unknown size", size: -1, wantChunked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatalf("Unexpected error reading body: %v", err)
+				}
+				chunked := len(r.TransferEncoding) > 0 && r.TransferEncoding[0] == "chunked"
+				if chunked != tt.wantChunked {
+					t.Errorf("Expected chunked %v, got transfer encoding %v", tt.wantChunked, r.TransferEncoding)
+				}
+				if !tt.wantChunked && r.ContentLength != int64(len(body)) {
+					t.Errorf("Expected Content-Length %d, got %d", len(body), r.ContentLength)
+				}
+				w.Write([]byte(`{"actionResponse":{"status":"Success","appId":"com.example.app"}}`))
+			}))
+			defer server.Close()
+
+			if _, err := UploadEnterpriseApp(server.URL, "1234", "app.ipa", strings.NewReader(binary), tt.size, meta, nil, "token"); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Package bulk runs a device action against many devices concurrently and reports
+// the outcome for each device.
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"maas360api/client"
+	"maas360api/devices"
+)
+
+// DefaultWorkers is the number of concurrent workers used when Options.Workers is not set.
+const DefaultWorkers = 5
+
+// DefaultRatePerSecond is the request rate used when Options.RatePerSecond is not set.
+const DefaultRatePerSecond = 5
+
+// Action is an operation performed on a single device.
+type Action struct {
+	Name string
+	Run  func(deviceID string) (*devices.ActionResult, error)
+}
+
+// Options controls how Run executes an action.
+type Options struct {
+	Workers       int     // Maximum number of concurrent requests
+	RatePerSecond float64 // Maximum number of requests started per second across all workers of one Run
+	DryRun        bool    // Report the devices that would be targeted without running the action
+}
+
+// Lock returns an Action that locks each device.
+func Lock(c *client.MaaS360Client) Action {
+	return Action{Name: "lock", Run: c.LockDevice}
+}
+
+// Message returns an Action that sends the same message to each device.
+func Message(c *client.MaaS360Client, messageTitle string, message string) Action {
+	return Action{
+		Name: "message",
+		Run: func(deviceID string) (*devices.ActionResult, error) {
+			return c.SendMessage(deviceID, messageTitle, message)
+		},
+	}
+}
+
+// UpdateOS returns an Action that schedules an OS update on each device.
+func UpdateOS(c *client.MaaS360Client, osVersion string, targetLocalTime time.Time) Action {
+	return Action{
+		Name: "updateOS",
+		Run: func(deviceID string) (*devices.ActionResult, error) {
+			if err := c.UpdateOS(deviceID, osVersion, targetLocalTime); err != nil {
+				return nil, err
+			}
+			return &devices.ActionResult{DeviceID: deviceID}, nil
+		},
+	}
+}
+
+// Select returns the IDs of every device matching the search filters.
+// The filters are those accepted by devices.SearchDevices.
+func Select(c *client.MaaS360Client, filters map[string]string) ([]string, error) {
+	found, err := c.SearchAllDevices(filters)
+	if err != nil {
+		return nil, fmt.Errorf("error selecting devices: %v", err)
+	}
+	return deviceIDs(found), nil
+}
+
+// SelectGroup returns the IDs of every device in a device group that matches the search
+// filters. With no filters, every device in the group is selected.
+func SelectGroup(c *client.MaaS360Client, groupID string, filters map[string]string) ([]string, error) {
+	found, err := c.SearchGroupDevices(groupID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("error selecting devices of group %s: %v", groupID, err)
+	}
+	return deviceIDs(found), nil
+}
+
+func deviceIDs(found []devices.Device) []string {
+	ids := make([]string, 0, len(found))
+	for _, device := range found {
+		if id := device.ID.String(); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Run performs the action on every device, using a bounded pool of workers whose
+// requests are paced by a shared rate limiter. A failure on one device does not stop
+// the others. Devices not yet started when ctx is cancelled are reported as skipped.
+func Run(ctx context.Context, deviceIDs []string, action Action, opts Options) *Report {
+	if opts.Workers <= 0 {
+		opts.Workers = DefaultWorkers
+	}
+	if opts.RatePerSecond <= 0 {
+		opts.RatePerSecond = DefaultRatePerSecond
+	}
+
+	report := &Report{
+		Action:    action.Name,
+		DryRun:    opts.DryRun,
+		StartedAt: time.Now(),
+		Results:   make([]Result, len(deviceIDs)),
+	}
+
+	interval := time.Duration(float64(time.Second) / opts.RatePerSecond)
+	if interval <= 0 {
+		interval = time.Nanosecond
+	}
+	limiter := time.NewTicker(interval)
+	defer limiter.Stop()
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				report.Results[i] = runOne(ctx, deviceIDs[i], action, opts.DryRun, limiter.C)
+			}
+		}()
+	}
+
+	for i := range deviceIDs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	report.FinishedAt = time.Now()
+	return report
+}
+
+// runOne waits for the rate limiter and performs the action on a single device.
+func runOne(ctx context.Context, deviceID string, action Action, dryRun bool, limiter <-chan time.Time) Result {
+	result := Result{DeviceID: deviceID, Action: action.Name}
+	if dryRun {
+		result.Status = StatusDryRun
+		return result
+	}
+
+	select {
+	case <-ctx.Done():
+		result.Status = StatusSkipped
+		result.Error = ctx.Err().Error()
+		return result
+	case <-limiter:
+	}
+
+	start := time.Now()
+	actionResult, err := action.Run(deviceID)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		return result
+	}
+	result.Status = StatusSucceeded
+	if actionResult != nil {
+		result.ActionID = actionResult.ActionID
+		result.Description = actionResult.Description
+	}
+	return result
+}
@@ -0,0 +1,156 @@
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"maas360api/devices"
+)
+
+func TestRun_ContinuesPastFailures(t *testing.T) {
+	deviceIDs := []string{"dev1", "dev2", "dev3", "dev4"}
+	action := Action{
+		Name: "test",
+		Run: func(deviceID string) (*devices.ActionResult, error) {
+			if deviceID == "dev2" {
+				return nil, fmt.Errorf("device offline")
+			}
+			return &devices.ActionResult{DeviceID: deviceID, ActionID: "id-" + deviceID}, nil
+		},
+	}
+
+	report := Run(context.Background(), deviceIDs, action, Options{Workers: 2, RatePerSecond: 1000})
+
+	if len(report.Results) != len(deviceIDs) {
+		t.Fatalf("Expected %d results, got %d", len(deviceIDs), len(report.Results))
+	}
+	for i, result := range report.Results {
+		if result.DeviceID != deviceIDs[i] {
+			t.Errorf("Expected result %d for %s, got %s", i, deviceIDs[i], result.DeviceID)
+		}
+	}
+	if got := report.Count(StatusSucceeded); got != 3 {
+		t.Errorf("Expected 3 successes, got %d", got)
+	}
+	failed := report.Failed()
+	if len(failed) != 1 || failed[0].DeviceID != "dev2" || failed[0].Error != "device offline" {
+		t.Errorf("Expected dev2 to fail with 'device offline', got %+v", failed)
+	}
+	if report.Results[0].ActionID != "id-dev1" {
+		t.Errorf("Expected action ID id-dev1, got %s", report.Results[0].ActionID)
+	}
+}
+
+func TestRun_VeryHighRate(t *testing.T) {
+	action := Action{
+		Name: "test",
+		Run: func(deviceID string) (*devices.ActionResult, error) {
+			return &devices.ActionResult{DeviceID: deviceID}, nil
+		},
+	}
+
+	report := Run(context.Background(), []string{"dev1", "dev2"}, action, Options{RatePerSecond: 1e12})
+	if got := report.Count(StatusSucceeded); got != 2 {
+		t.Errorf("Expected 2 successes, got %d", got)
+	}
+}
+
+func TestRun_BoundsConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	var running, maxRunning int
+	action := Action{
+		Name: "test",
+		Run: func(deviceID string) (*devices.ActionResult, error) {
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil, nil
+		},
+	}
+
+	deviceIDs := make([]string, 20)
+	for i := range deviceIDs {
+		deviceIDs[i] = fmt.Sprintf("dev%d", i)
+	}
+	Run(context.Background(), deviceIDs, action, Options{Workers: 3, RatePerSecond: 10000})
+
+	if maxRunning > 3 {
+		t.Errorf("Expected at most 3 concurrent actions, got %d", maxRunning)
+	}
+}
+
+func TestRun_DryRun(t *testing.T) {
+	var calls int32
+	action := Action{
+		Name: "test",
+		Run: func(deviceID string) (*devices.ActionResult, error) {
+			atomic.AddInt32(&calls, 1)
+			return nil, nil
+		},
+	}
+
+	report := Run(context.Background(), []string{"dev1", "dev2"}, action, Options{DryRun: true})
+
+	if calls != 0 {
+		t.Errorf("Expected no calls in dry-run mode, got %d", calls)
+	}
+	if got := report.Count(StatusDryRun); got != 2 {
+		t.Errorf("Expected 2 dry-run results, got %d", got)
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	action := Action{
+		Name: "test",
+		Run: func(deviceID string) (*devices.ActionResult, error) {
+			return nil, nil
+		},
+	}
+
+	report := Run(ctx, []string{"dev1", "dev2"}, action, Options{RatePerSecond: 0.001})
+
+	if got := report.Count(StatusSkipped); got != 2 {
+		t.Errorf("Expected 2 skipped results, got %d", got)
+	}
+}
+
+func TestReport_WriteCSV(t *testing.T) {
+	report := &Report{
+		Action: "lock",
+		Results: []Result{
+			{DeviceID: "dev1", Action: "lock", Status: StatusSucceeded, ActionID: "42"},
+			{DeviceID: "dev2", Action: "lock", Status: StatusFailed, Error: "bad, \"quoted\" error"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(buf.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("Unexpected error reading CSV: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d rows", len(rows))
+	}
+	if rows[2][5] != "bad, \"quoted\" error" {
+		t.Errorf("Expected error column to round-trip, got %q", rows[2][5])
+	}
+}
@@ -0,0 +1,91 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Status is the outcome of an action on a single device.
+type Status string
+
+const (
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusSkipped   Status = "skipped"
+	StatusDryRun    Status = "dry-run"
+)
+
+// Result records the outcome of an action on a single device.
+type Result struct {
+	DeviceID    string        `json:"deviceId"`
+	Action      string        `json:"action"`
+	Status      Status        `json:"status"`
+	ActionID    string        `json:"actionId,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Error       string        `json:"error,omitempty"`
+	Duration    time.Duration `json:"durationNs"`
+}
+
+// Report collects the per-device results of a bulk run, in the order the devices were given.
+type Report struct {
+	Action     string    `json:"action"`
+	DryRun     bool      `json:"dryRun"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	Results    []Result  `json:"results"`
+}
+
+// Count returns the number of results with the given status.
+func (r *Report) Count(status Status) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Failed returns the results of devices the action failed on.
+func (r *Report) Failed() []Result {
+	var failed []Result
+	for _, result := range r.Results {
+		if result.Status == StatusFailed {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteCSV writes one row per device with a header row.
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"deviceId", "action", "status", "actionId", "description", "error", "duration"}); err != nil {
+		return err
+	}
+	for _, result := range r.Results {
+		row := []string{
+			result.DeviceID,
+			result.Action,
+			string(result.Status),
+			result.ActionID,
+			result.Description,
+			result.Error,
+			result.Duration.String(),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
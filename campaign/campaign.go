@@ -0,0 +1,268 @@
+// Package campaign rolls out OS updates to a fleet of devices in rings, each with its own
+// maintenance window, and tracks progress in a resumable state file.
+package campaign
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+
+	"maas360api/devices"
+	"maas360api/types"
+)
+
+// Client is the subset of the MaaS360 client used by a campaign. *client.MaaS360Client satisfies it.
+type Client interface {
+	SearchAllDevices(filters map[string]string) ([]devices.Device, error)
+	ListAllGroupDevices(groupID string) ([]devices.Device, error)
+	UpdateOS(deviceID string, osVersion string, targetLocalTime time.Time) error
+}
+
+// Window is the maintenance window in which a ring's devices install the update.
+type Window struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Ring is a group of devices updated together.
+// A device listed in DeviceIDs, or a member of the MaaS360 device group GroupID, joins that
+// ring; the remaining devices are split between the rings with a Percent, in the order the
+// rings are given. When no ring has a Percent, the remaining devices join the last ring.
+type Ring struct {
+	Name      string   `json:"name"`
+	Percent   int      `json:"percent,omitempty"`
+	DeviceIDs []string `json:"deviceIds,omitempty"`
+	GroupID   string   `json:"groupId,omitempty"`
+	Window    Window   `json:"window"`
+}
+
+// Campaign describes an OS update rollout.
+type Campaign struct {
+	Name          string
+	TargetVersion string            // OS version to install, passed to devices.UpdateOS
+	Filters       map[string]string // Device search filters selecting the campaign's devices
+	Rings         []Ring            // Rings in rollout order, for example pilot, early and broad
+	StatePath     string            // File the campaign state is saved to and resumed from
+}
+
+// Validate checks the campaign definition.
+func (c *Campaign) Validate() error {
+	if c.Name == "" || c.TargetVersion == "" || c.StatePath == "" {
+		return fmt.Errorf("name, targetVersion and statePath must not be empty")
+	}
+	if _, err := types.ParseOSVersion(c.TargetVersion); err != nil {
+		return fmt.Errorf("invalid target version: %v", err)
+	}
+	if len(c.Rings) == 0 {
+		return fmt.Errorf("at least one ring is required")
+	}
+	names := map[string]bool{}
+	total := 0
+	for _, ring := range c.Rings {
+		if ring.Name == "" {
+			return fmt.Errorf("ring name must not be empty")
+		}
+		if names[ring.Name] {
+			return fmt.Errorf("duplicate ring name %s", ring.Name)
+		}
+		names[ring.Name] = true
+		if ring.Percent < 0 {
+			return fmt.Errorf("ring %s has a negative percent", ring.Name)
+		}
+		if ring.Window.Start.IsZero() || !ring.Window.End.After(ring.Window.Start) {
+			return fmt.Errorf("ring %s needs a maintenance window with an end after its start", ring.Name)
+		}
+		total += ring.Percent
+	}
+	if total != 0 && total != 100 {
+		return fmt.Errorf("ring percentages must add up to 100, got %d", total)
+	}
+	return nil
+}
+
+// Start loads the campaign state from StatePath, or, when no state has been saved yet,
+// selects the campaign's devices, assigns them to rings and saves the new state.
+func Start(client Client, c *Campaign) (*State, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	state, err := LoadState(c.StatePath)
+	if err != nil {
+		return nil, err
+	}
+	if state != nil {
+		if state.Name != c.Name || state.TargetVersion != c.TargetVersion {
+			return nil, fmt.Errorf("state file %s belongs to campaign %s (%s)", c.StatePath, state.Name, state.TargetVersion)
+		}
+		return state, nil
+	}
+
+	found, err := client.SearchAllDevices(c.Filters)
+	if err != nil {
+		return nil, fmt.Errorf("error selecting devices: %v", err)
+	}
+	deviceIDs := make([]string, 0, len(found))
+	for _, device := range found {
+		if id := device.ID.String(); id != "" {
+			deviceIDs = append(deviceIDs, id)
+		}
+	}
+
+	rings, err := resolveGroups(client, c.Rings)
+	if err != nil {
+		return nil, err
+	}
+
+	state = &State{
+		Name:          c.Name,
+		TargetVersion: c.TargetVersion,
+		Rings:         c.Rings,
+		Devices:       map[string]*DeviceState{},
+	}
+	for id, ring := range assignRings(deviceIDs, rings) {
+		state.Devices[id] = &DeviceState{Ring: ring}
+	}
+	if err := state.Save(c.StatePath); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Schedule calls UpdateOS for every device in the ring that has not been scheduled yet,
+// targeting the start of the ring's maintenance window, or the current time once the window
+// has started. Failures are recorded in the state and do not stop the remaining devices. The
+// state is saved after each device, so an interrupted run can be resumed by calling Schedule
+// again. Once the ring's window has ended nothing is scheduled and an error is returned.
+func Schedule(client Client, c *Campaign, state *State, ringName string) error {
+	ring, ok := state.ring(ringName)
+	if !ok {
+		return fmt.Errorf("ring %s not found", ringName)
+	}
+	if time.Now().After(ring.Window.End) {
+		return fmt.Errorf("maintenance window of ring %s ended at %v", ringName, ring.Window.End)
+	}
+
+	for _, id := range state.DeviceIDs(ringName) {
+		device := state.Devices[id]
+		if device.Scheduled {
+			continue
+		}
+		target := ring.Window.Start
+		if now := time.Now(); now.After(target) {
+			target = now
+		}
+		if err := client.UpdateOS(id, state.TargetVersion, target); err != nil {
+			device.Error = err.Error()
+		} else {
+			device.Scheduled = true
+			device.ScheduledAt = time.Now()
+			device.Error = ""
+		}
+		if err := state.Save(c.StatePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Refresh re-reads the OS version of every device in the campaign and marks the devices
+// that have reached the target version as completed.
+func Refresh(client Client, c *Campaign, state *State) error {
+	found, err := client.SearchAllDevices(c.Filters)
+	if err != nil {
+		return fmt.Errorf("error refreshing devices: %v", err)
+	}
+	target, err := types.ParseOSVersion(state.TargetVersion)
+	if err != nil {
+		return fmt.Errorf("invalid target version: %v", err)
+	}
+	for _, device := range found {
+		deviceState, ok := state.Devices[device.ID.String()]
+		if !ok || !device.OSVersion.IsSet() {
+			continue
+		}
+		deviceState.OSVersion = device.OSVersion.String()
+		deviceState.Completed = device.OSVersion.Compare(target) >= 0
+	}
+	return state.Save(c.StatePath)
+}
+
+// resolveGroups returns a copy of rings in which the members of each ring's GroupID are added
+// to its DeviceIDs.
+func resolveGroups(client Client, rings []Ring) ([]Ring, error) {
+	resolved := make([]Ring, len(rings))
+	for i, ring := range rings {
+		resolved[i] = ring
+		if ring.GroupID == "" {
+			continue
+		}
+		members, err := client.ListAllGroupDevices(ring.GroupID)
+		if err != nil {
+			return nil, fmt.Errorf("error listing devices of group %s for ring %s: %v", ring.GroupID, ring.Name, err)
+		}
+		ids := append([]string(nil), ring.DeviceIDs...)
+		for _, device := range members {
+			if id := device.ID.String(); id != "" {
+				ids = append(ids, id)
+			}
+		}
+		resolved[i].DeviceIDs = ids
+	}
+	return resolved, nil
+}
+
+// assignRings maps each device ID to a ring name. Devices listed explicitly in a ring go to
+// that ring; the rest are ordered by a hash of their ID, so the split is stable between runs
+// and not biased by platform, and divided between the percentage rings. Devices left over
+// when no ring has a percentage go to the last ring.
+func assignRings(deviceIDs []string, rings []Ring) map[string]string {
+	assigned := map[string]string{}
+	listed := map[string]string{}
+	for _, ring := range rings {
+		for _, id := range ring.DeviceIDs {
+			if _, ok := listed[id]; !ok {
+				listed[id] = ring.Name
+			}
+		}
+	}
+
+	var remaining []string
+	for _, id := range deviceIDs {
+		if ring, ok := listed[id]; ok {
+			assigned[id] = ring
+		} else {
+			remaining = append(remaining, id)
+		}
+	}
+	sort.Slice(remaining, func(i, j int) bool {
+		hi, hj := hashID(remaining[i]), hashID(remaining[j])
+		if hi != hj {
+			return hi < hj
+		}
+		return remaining[i] < remaining[j]
+	})
+
+	cumulative, start := 0, 0
+	for _, ring := range rings {
+		if ring.Percent == 0 {
+			continue
+		}
+		cumulative += ring.Percent
+		end := (len(remaining)*cumulative + 50) / 100
+		for _, id := range remaining[start:end] {
+			assigned[id] = ring.Name
+		}
+		start = end
+	}
+	for _, id := range remaining[start:] {
+		assigned[id] = rings[len(rings)-1].Name
+	}
+	return assigned
+}
+
+func hashID(id string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	return h.Sum32()
+}
@@ -0,0 +1,232 @@
+package campaign
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"maas360api/devices"
+	"maas360api/types"
+)
+
+type fakeClient struct {
+	devices   []devices.Device
+	groups    map[string][]devices.Device
+	scheduled map[string]time.Time
+	failures  map[string]bool
+}
+
+func (f *fakeClient) SearchAllDevices(filters map[string]string) ([]devices.Device, error) {
+	return f.devices, nil
+}
+
+func (f *fakeClient) ListAllGroupDevices(groupID string) ([]devices.Device, error) {
+	members, ok := f.groups[groupID]
+	if !ok {
+		return nil, fmt.Errorf("group %s not found", groupID)
+	}
+	return members, nil
+}
+
+func (f *fakeClient) UpdateOS(deviceID string, osVersion string, targetLocalTime time.Time) error {
+	if f.failures[deviceID] {
+		return fmt.Errorf("device %s unreachable", deviceID)
+	}
+	f.scheduled[deviceID] = targetLocalTime
+	return nil
+}
+
+func newFakeClient(n int) *fakeClient {
+	f := &fakeClient{scheduled: map[string]time.Time{}, failures: map[string]bool{}}
+	for i := 1; i <= n; i++ {
+		f.devices = append(f.devices, devices.Device{ID: types.FlexibleString{Value: fmt.Sprint(i), IsSet: true}, OSVersion: types.MustParseOSVersion("17.1")})
+	}
+	return f
+}
+
+func testCampaign(t *testing.T) *Campaign {
+	start := time.Now().UTC().Truncate(time.Hour).Add(24 * time.Hour)
+	window := func(days int) Window {
+		s := start.AddDate(0, 0, days)
+		return Window{Start: s, End: s.Add(4 * time.Hour)}
+	}
+	return &Campaign{
+		Name:          "ios-17.4",
+		TargetVersion: "17.4",
+		Rings: []Ring{
+			{Name: "pilot", Percent: 10, DeviceIDs: []string{"100"}, Window: window(0)},
+			{Name: "early", Percent: 30, Window: window(2)},
+			{Name: "broad", Percent: 60, Window: window(7)},
+		},
+		StatePath: filepath.Join(t.TempDir(), "state.json"),
+	}
+}
+
+func TestAssignRings(t *testing.T) {
+	c := testCampaign(t)
+	var ids []string
+	for i := 1; i <= 100; i++ {
+		ids = append(ids, fmt.Sprint(i))
+	}
+
+	assigned := assignRings(ids, c.Rings)
+
+	counts := map[string]int{}
+	for _, ring := range assigned {
+		counts[ring]++
+	}
+	if assigned["100"] != "pilot" {
+		t.Errorf("Expected explicitly listed device to be in pilot, got %s", assigned["100"])
+	}
+	// 99 devices remain after the explicit member: 10% of them (10) plus the explicit one.
+	if counts["pilot"] != 11 || counts["early"] != 30 || counts["broad"] != 59 {
+		t.Errorf("Unexpected ring sizes: %v", counts)
+	}
+
+	again := assignRings(ids, c.Rings)
+	for id, ring := range assigned {
+		if again[id] != ring {
+			t.Fatalf("Expected stable assignment for %s, got %s then %s", id, ring, again[id])
+		}
+	}
+}
+
+func TestAssignRings_NoPercentages(t *testing.T) {
+	c := testCampaign(t)
+	for i := range c.Rings {
+		c.Rings[i].Percent = 0
+	}
+
+	assigned := assignRings([]string{"1", "2", "100"}, c.Rings)
+
+	if assigned["100"] != "pilot" {
+		t.Errorf("Expected explicitly listed device to be in pilot, got %s", assigned["100"])
+	}
+	if assigned["1"] != "broad" || assigned["2"] != "broad" {
+		t.Errorf("Expected unlisted devices in the last ring, got %v", assigned)
+	}
+}
+
+func TestCampaign_ScheduleAndResume(t *testing.T) {
+	c := testCampaign(t)
+	client := newFakeClient(20)
+	client.failures["3"] = true
+
+	state, err := Start(client, c)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, ring := range c.Rings {
+		if err := Schedule(client, c, state, ring.Name); err != nil {
+			t.Fatalf("Unexpected error scheduling %s: %v", ring.Name, err)
+		}
+	}
+	if len(client.scheduled) != 19 {
+		t.Errorf("Expected 19 devices scheduled, got %d", len(client.scheduled))
+	}
+	for id, when := range client.scheduled {
+		ring, _ := state.ring(state.Devices[id].Ring)
+		if !when.Equal(ring.Window.Start) {
+			t.Errorf("Expected device %s scheduled at %v, got %v", id, ring.Window.Start, when)
+		}
+	}
+
+	// Resuming from the state file retries only the failed device.
+	delete(client.failures, "3")
+	client.scheduled = map[string]time.Time{}
+	resumed, err := Start(client, c)
+	if err != nil {
+		t.Fatalf("Unexpected error resuming: %v", err)
+	}
+	if err := Schedule(client, c, resumed, resumed.Devices["3"].Ring); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(client.scheduled) != 1 {
+		t.Errorf("Expected only the failed device to be rescheduled, got %v", client.scheduled)
+	}
+
+	client.devices[0].OSVersion = types.MustParseOSVersion("17.4")
+	client.devices[1].OSVersion = types.MustParseOSVersion("17.4.1")
+	if err := Refresh(client, c, resumed); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	completed := 0
+	for _, p := range resumed.Progress() {
+		completed += p.Completed
+	}
+	if completed != 2 {
+		t.Errorf("Expected 2 completed devices, got %d", completed)
+	}
+}
+
+func TestStart_GroupRing(t *testing.T) {
+	c := testCampaign(t)
+	c.Rings[0].GroupID = "42"
+	client := newFakeClient(20)
+	client.groups = map[string][]devices.Device{"42": {client.devices[4], client.devices[9]}}
+
+	state, err := Start(client, c)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, id := range []string{"5", "10"} {
+		if ring := state.Devices[id].Ring; ring != "pilot" {
+			t.Errorf("Expected group member %s in pilot, got %s", id, ring)
+		}
+	}
+
+	c = testCampaign(t)
+	c.Rings[0].GroupID = "missing"
+	if _, err := Start(client, c); err == nil {
+		t.Error("Expected error for unknown group")
+	}
+}
+
+func TestSchedule_WindowStarted(t *testing.T) {
+	c := testCampaign(t)
+	c.Rings[0].Window = Window{Start: time.Now().Add(-time.Hour), End: time.Now().Add(time.Hour)}
+	client := newFakeClient(20)
+
+	state, err := Start(client, c)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	before := time.Now()
+	if err := Schedule(client, c, state, "pilot"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(client.scheduled) == 0 {
+		t.Fatal("Expected devices to be scheduled")
+	}
+	for id, when := range client.scheduled {
+		if when.Before(before) {
+			t.Errorf("Expected device %s scheduled no earlier than now, got %v", id, when)
+		}
+	}
+}
+
+func TestSchedule_WindowEnded(t *testing.T) {
+	c := testCampaign(t)
+	c.Rings[0].Window = Window{Start: time.Now().Add(-5 * time.Hour), End: time.Now().Add(-time.Hour)}
+	client := newFakeClient(20)
+
+	state, err := Start(client, c)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := Schedule(client, c, state, "pilot"); err == nil {
+		t.Error("Expected error scheduling a ring whose window has ended")
+	}
+	if len(client.scheduled) != 0 {
+		t.Errorf("Expected no devices scheduled, got %v", client.scheduled)
+	}
+}
+
+func TestCampaign_Validate(t *testing.T) {
+	c := testCampaign(t)
+	c.Rings[2].Percent = 50
+	if err := c.Validate(); err == nil {
+		t.Error("Expected error when percentages do not add up to 100")
+	}
+}
@@ -0,0 +1,126 @@
+package campaign
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DeviceState tracks a single device through the campaign.
+type DeviceState struct {
+	Ring        string    `json:"ring"`
+	Scheduled   bool      `json:"scheduled"`
+	ScheduledAt time.Time `json:"scheduledAt,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	OSVersion   string    `json:"osVersion,omitempty"`
+	Completed   bool      `json:"completed"`
+}
+
+// State is the persisted progress of a campaign.
+type State struct {
+	Name          string                  `json:"name"`
+	TargetVersion string                  `json:"targetVersion"`
+	Rings         []Ring                  `json:"rings"`
+	Devices       map[string]*DeviceState `json:"devices"`
+}
+
+// RingProgress summarizes the devices of one ring.
+type RingProgress struct {
+	Ring      string
+	Window    Window
+	Total     int
+	Scheduled int
+	Failed    int
+	Completed int
+}
+
+// LoadState reads a campaign state file. It returns nil and no error if the file does not exist.
+func LoadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading state file: %v", err)
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("error unmarshaling state file %s: %v", path, err)
+	}
+	if state.Devices == nil {
+		state.Devices = map[string]*DeviceState{}
+	}
+	return &state, nil
+}
+
+// Save writes the state to path, replacing the previous file only once the new one is fully written.
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling state: %v", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("error writing state file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing state file: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing state file: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error writing state file: %v", err)
+	}
+	return nil
+}
+
+// DeviceIDs returns the sorted IDs of the devices assigned to a ring.
+func (s *State) DeviceIDs(ringName string) []string {
+	var ids []string
+	for id, device := range s.Devices {
+		if device.Ring == ringName {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Progress returns a summary per ring, in rollout order.
+func (s *State) Progress() []RingProgress {
+	progress := make([]RingProgress, 0, len(s.Rings))
+	for _, ring := range s.Rings {
+		p := RingProgress{Ring: ring.Name, Window: ring.Window}
+		for _, id := range s.DeviceIDs(ring.Name) {
+			device := s.Devices[id]
+			p.Total++
+			if device.Scheduled {
+				p.Scheduled++
+			}
+			if device.Error != "" {
+				p.Failed++
+			}
+			if device.Completed {
+				p.Completed++
+			}
+		}
+		progress = append(progress, p)
+	}
+	return progress
+}
+
+func (s *State) ring(name string) (Ring, bool) {
+	for _, ring := range s.Rings {
+		if ring.Name == name {
+			return ring, true
+		}
+	}
+	return Ring{}, false
+}
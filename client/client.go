@@ -2,12 +2,18 @@ package client
 
 import (
 	"errors"
+	"io"
 	"time"
 
 	"maas360api/application"
 	"maas360api/auth"
+	"maas360api/compliance"
 	"maas360api/devices"
+	"maas360api/enrollment"
+	"maas360api/groups"
 	"maas360api/internal/constants"
+	"maas360api/policies"
+	"maas360api/users"
 )
 
 // MaaS360Client represents a MaaS360 API client with authentication credentials
@@ -21,6 +27,10 @@ type MaaS360Client struct {
 	RefreshToken string // Refresh token for token-based authentication
 	MaasToken    string // Current authentication token
 	ServiceURL   string // Base URL for the MaaS360 API service
+
+	// ActionOptions are applied to PerformDeviceAction and UpdateOS, for example to cache
+	// each device's available actions during bulk jobs.
+	ActionOptions devices.ActionOptions
 }
 
 // GetBasicauth generates a Basic Authentication header value for the client's credentials.
@@ -68,14 +78,14 @@ func (c *MaaS360Client) GetDeviceActions(deviceID string) (*devices.DeviceAction
 }
 
 func (c *MaaS360Client) PerformDeviceAction(deviceID string, actionID string, additionalParams map[string]string) error {
-	return devices.PerformDeviceAction(c.ServiceURL, c.BillingID, deviceID, actionID, additionalParams, c.MaasToken)
+	return devices.PerformDeviceActionWithOptions(c.ServiceURL, c.BillingID, deviceID, actionID, additionalParams, c.ActionOptions, c.MaasToken)
 }
 
-func (c *MaaS360Client) SendMessage(deviceID string, subject string, message string) error {
+func (c *MaaS360Client) SendMessage(deviceID string, subject string, message string) (*devices.ActionResult, error) {
 	return devices.SendMessage(c.ServiceURL, c.BillingID, deviceID, subject, message, c.MaasToken)
 }
 
-func (c *MaaS360Client) LockDevice(deviceID string) error {
+func (c *MaaS360Client) LockDevice(deviceID string) (*devices.ActionResult, error) {
 	return devices.LockDevice(c.ServiceURL, c.BillingID, deviceID, c.MaasToken)
 }
 
@@ -124,10 +134,10 @@ func (c *MaaS360Client) PrintAllSoftwareInstalled(filters map[string]string) {
 }
 
 func (c *MaaS360Client) UpdateOS(deviceID string, osVersion string, targetLocalTime time.Time) error {
-	return devices.UpdateOS(c.ServiceURL, c.BillingID, deviceID, osVersion, targetLocalTime, c.MaasToken)
+	return devices.UpdateOSWithOptions(c.ServiceURL, c.BillingID, deviceID, osVersion, targetLocalTime, c.ActionOptions, c.MaasToken)
 }
 
-func (c *MaaS360Client) GetNetworkInfo(deviceID string) ([]devices.DeviceAttribute, error) {
+func (c *MaaS360Client) GetNetworkInfo(deviceID string) (devices.Attributes, error) {
 	return devices.GetNetworkInfo(c.ServiceURL, c.BillingID, deviceID, c.MaasToken)
 }
 func (c *MaaS360Client) PrintNetworkInfo(deviceID string) {
@@ -143,6 +153,258 @@ func (c *MaaS360Client) PrintDeviceAttributes(deviceID string) {
 
 }
 
-func (c *MaaS360Client) HideDevice(deviceID string) error {
+func (c *MaaS360Client) HideDevice(deviceID string) (*devices.ActionResult, error) {
 	return devices.HideDevice(c.ServiceURL, c.BillingID, deviceID, c.MaasToken)
 }
+
+func (c *MaaS360Client) RenameDevice(deviceID string, name string) (*devices.ActionResult, error) {
+	return devices.RenameDevice(c.ServiceURL, c.BillingID, deviceID, name, c.MaasToken)
+}
+
+func (c *MaaS360Client) SetDeviceOwnership(deviceID string, ownership devices.Ownership) (*devices.ActionResult, error) {
+	return devices.SetDeviceOwnership(c.ServiceURL, c.BillingID, deviceID, ownership, c.MaasToken)
+}
+
+func (c *MaaS360Client) SetTestDevice(deviceID string, testDevice bool) (*devices.ActionResult, error) {
+	return devices.SetTestDevice(c.ServiceURL, c.BillingID, deviceID, testDevice, c.MaasToken)
+}
+
+func (c *MaaS360Client) WipeDevice(deviceID string, opts devices.WipeOptions) (*devices.WipeResult, error) {
+	return devices.WipeDevice(c.ServiceURL, c.BillingID, deviceID, opts, c.MaasToken)
+}
+
+func (c *MaaS360Client) SelectiveWipeDevice(deviceID string, opts devices.WipeOptions) (*devices.WipeResult, error) {
+	return devices.SelectiveWipeDevice(c.ServiceURL, c.BillingID, deviceID, opts, c.MaasToken)
+}
+
+func (c *MaaS360Client) CancelPendingWipe(deviceID string, opts devices.WipeOptions) (*devices.WipeResult, error) {
+	return devices.CancelPendingWipe(c.ServiceURL, c.BillingID, deviceID, opts, c.MaasToken)
+}
+
+func (c *MaaS360Client) LocateDevice(deviceID string) (*devices.Location, error) {
+	return devices.LocateDevice(c.ServiceURL, c.BillingID, deviceID, c.MaasToken)
+}
+
+func (c *MaaS360Client) GetLocationHistory(deviceID string) ([]devices.Location, error) {
+	return devices.GetLocationHistory(c.ServiceURL, c.BillingID, deviceID, c.MaasToken)
+}
+
+func (c *MaaS360Client) ResetPasscode(deviceID string) (*devices.ActionResult, error) {
+	return devices.ResetPasscode(c.ServiceURL, c.BillingID, deviceID, c.MaasToken)
+}
+
+func (c *MaaS360Client) ClearPasscode(deviceID string) (*devices.ActionResult, error) {
+	return devices.ClearPasscode(c.ServiceURL, c.BillingID, deviceID, c.MaasToken)
+}
+
+func (c *MaaS360Client) BuzzDevice(deviceID string) (*devices.ActionResult, error) {
+	return devices.BuzzDevice(c.ServiceURL, c.BillingID, deviceID, c.MaasToken)
+}
+
+func (c *MaaS360Client) RefreshDeviceInformation(deviceID string) (*devices.ActionResult, error) {
+	return devices.RefreshDeviceInformation(c.ServiceURL, c.BillingID, deviceID, c.MaasToken)
+}
+
+func (c *MaaS360Client) StopMailboxSync(deviceID string) (*devices.ActionResult, error) {
+	return devices.StopMailboxSync(c.ServiceURL, c.BillingID, deviceID, c.MaasToken)
+}
+
+func (c *MaaS360Client) StartMailboxSync(deviceID string) (*devices.ActionResult, error) {
+	return devices.StartMailboxSync(c.ServiceURL, c.BillingID, deviceID, c.MaasToken)
+}
+
+func (c *MaaS360Client) SendTemplatedMessage(device devices.Device, titleTemplate string, messageTemplate string) (*devices.ActionResult, error) {
+	return devices.SendTemplatedMessage(c.ServiceURL, c.BillingID, device, titleTemplate, messageTemplate, c.MaasToken)
+}
+
+func (c *MaaS360Client) SearchAllDevices(filters map[string]string) ([]devices.Device, error) {
+	return devices.SearchAllDevices(c.ServiceURL, c.BillingID, filters, c.MaasToken)
+}
+
+func (c *MaaS360Client) ListCustomAttributeDefinitions() ([]devices.CustomAttributeDefinition, error) {
+	return devices.ListCustomAttributeDefinitions(c.ServiceURL, c.BillingID, c.MaasToken)
+}
+
+func (c *MaaS360Client) SetCustomAttribute(deviceID string, name string, value string) (*devices.ActionResult, error) {
+	return devices.SetCustomAttribute(c.ServiceURL, c.BillingID, deviceID, name, value, c.MaasToken)
+}
+
+func (c *MaaS360Client) SetCustomAttributes(updates []devices.CustomAttributeUpdate) ([]devices.CustomAttributeResult, error) {
+	return devices.SetCustomAttributes(c.ServiceURL, c.BillingID, updates, c.MaasToken)
+}
+
+func (c *MaaS360Client) UpdateDeviceIdentity(deviceID string, patch devices.DeviceIdentityPatch) (*devices.IdentityUpdate, error) {
+	return devices.UpdateDeviceIdentity(c.ServiceURL, c.BillingID, deviceID, patch, c.MaasToken)
+}
+
+func (c *MaaS360Client) PlanAssetSync(records io.Reader) (*devices.AssetSyncPlan, error) {
+	return devices.PlanAssetSync(c.ServiceURL, c.BillingID, records, c.MaasToken)
+}
+
+func (c *MaaS360Client) ApplyAssetSync(plan *devices.AssetSyncPlan) []devices.AssetSyncResult {
+	return devices.ApplyAssetSync(c.ServiceURL, c.BillingID, plan, c.MaasToken)
+}
+
+func (c *MaaS360Client) SearchUsers(filters map[string]string) ([]users.User, error) {
+	return users.SearchUsers(c.ServiceURL, c.BillingID, filters, c.MaasToken)
+}
+
+func (c *MaaS360Client) SearchAllUsers(filters map[string]string) ([]users.User, error) {
+	return users.SearchAllUsers(c.ServiceURL, c.BillingID, filters, c.MaasToken)
+}
+
+func (c *MaaS360Client) GetUser(userIdentifier string) (*users.User, error) {
+	return users.GetUser(c.ServiceURL, c.BillingID, userIdentifier, c.MaasToken)
+}
+
+func (c *MaaS360Client) AddLocalUser(user users.LocalUser) (*users.Result, error) {
+	return users.AddLocalUser(c.ServiceURL, c.BillingID, user, c.MaasToken)
+}
+
+func (c *MaaS360Client) UpdateUser(userIdentifier string, update users.UserUpdate) (*users.Result, error) {
+	return users.UpdateUser(c.ServiceURL, c.BillingID, userIdentifier, update, c.MaasToken)
+}
+
+func (c *MaaS360Client) DeleteUser(userIdentifier string) (*users.Result, error) {
+	return users.DeleteUser(c.ServiceURL, c.BillingID, userIdentifier, c.MaasToken)
+}
+
+func (c *MaaS360Client) GetUserDevices(user users.User) ([]devices.Device, error) {
+	return users.GetUserDevices(c.ServiceURL, c.BillingID, user, c.MaasToken)
+}
+
+func (c *MaaS360Client) UserForDevice(device devices.Device) (*users.User, error) {
+	return users.UserForDevice(c.ServiceURL, c.BillingID, device, c.MaasToken)
+}
+
+func (c *MaaS360Client) ListGroups() ([]groups.Group, error) {
+	return groups.ListGroups(c.ServiceURL, c.BillingID, c.MaasToken)
+}
+
+func (c *MaaS360Client) ListDeviceGroups() ([]groups.Group, error) {
+	return groups.ListDeviceGroups(c.ServiceURL, c.BillingID, c.MaasToken)
+}
+
+func (c *MaaS360Client) ListUserGroups() ([]groups.Group, error) {
+	return groups.ListUserGroups(c.ServiceURL, c.BillingID, c.MaasToken)
+}
+
+func (c *MaaS360Client) ListGroupDevices(groupID string, pageNumber int, pageSize int) ([]devices.Device, int, error) {
+	return groups.ListGroupDevices(c.ServiceURL, c.BillingID, groupID, pageNumber, pageSize, c.MaasToken)
+}
+
+func (c *MaaS360Client) ListAllGroupDevices(groupID string) ([]devices.Device, error) {
+	return groups.ListAllGroupDevices(c.ServiceURL, c.BillingID, groupID, c.MaasToken)
+}
+
+func (c *MaaS360Client) SearchGroupDevices(groupID string, filters map[string]string) ([]devices.Device, error) {
+	return groups.SearchGroupDevices(c.ServiceURL, c.BillingID, groupID, filters, c.MaasToken)
+}
+
+func (c *MaaS360Client) AddUsersToGroup(groupID string, userIdentifiers []string) (*groups.MembershipResult, error) {
+	return groups.AddUsersToGroup(c.ServiceURL, c.BillingID, groupID, userIdentifiers, c.MaasToken)
+}
+
+func (c *MaaS360Client) RemoveUsersFromGroup(groupID string, userIdentifiers []string) (*groups.MembershipResult, error) {
+	return groups.RemoveUsersFromGroup(c.ServiceURL, c.BillingID, groupID, userIdentifiers, c.MaasToken)
+}
+
+func (c *MaaS360Client) ListPolicies(policyType policies.PolicyType) ([]policies.Policy, error) {
+	return policies.ListPolicies(c.ServiceURL, c.BillingID, policyType, c.MaasToken)
+}
+
+func (c *MaaS360Client) GetPolicy(policyType policies.PolicyType, policyName string) (*policies.Policy, error) {
+	return policies.GetPolicy(c.ServiceURL, c.BillingID, policyType, policyName, c.MaasToken)
+}
+
+func (c *MaaS360Client) PublishPolicy(policyType policies.PolicyType, policyName string) (*policies.PublishResult, error) {
+	return policies.PublishPolicy(c.ServiceURL, c.BillingID, policyType, policyName, c.MaasToken)
+}
+
+func (c *MaaS360Client) AssignDevicePolicy(deviceID string, policyType policies.PolicyType, policyName string) (*devices.ActionResult, error) {
+	return policies.AssignDevicePolicy(c.ServiceURL, c.BillingID, deviceID, policyType, policyName, c.MaasToken)
+}
+
+func (c *MaaS360Client) GetDeviceViolations(deviceID string) ([]compliance.Violation, error) {
+	return compliance.GetDeviceViolations(c.ServiceURL, c.BillingID, deviceID, c.MaasToken)
+}
+
+func (c *MaaS360Client) ListRuleSets() ([]compliance.RuleSet, error) {
+	return compliance.ListRuleSets(c.ServiceURL, c.BillingID, c.MaasToken)
+}
+
+func (c *MaaS360Client) ComplianceSummary(filters map[string]string) (*compliance.Summary, error) {
+	return compliance.SummaryReport(c.ServiceURL, c.BillingID, filters, c.MaasToken)
+}
+
+func (c *MaaS360Client) CreateEnrollment(request enrollment.Request) (*enrollment.Enrollment, error) {
+	return enrollment.Create(c.ServiceURL, c.BillingID, request, c.MaasToken)
+}
+
+func (c *MaaS360Client) CreateEnrollmentsFromCSV(records io.Reader) ([]enrollment.BulkResult, error) {
+	return enrollment.CreateFromCSV(c.ServiceURL, c.BillingID, records, c.MaasToken)
+}
+
+func (c *MaaS360Client) ListPendingEnrollments() ([]enrollment.Enrollment, error) {
+	return enrollment.ListPending(c.ServiceURL, c.BillingID, c.MaasToken)
+}
+
+func (c *MaaS360Client) ResendEnrollment(requestID string) error {
+	return enrollment.Resend(c.ServiceURL, c.BillingID, requestID, c.MaasToken)
+}
+
+func (c *MaaS360Client) CancelEnrollment(requestID string) error {
+	return enrollment.Cancel(c.ServiceURL, c.BillingID, requestID, c.MaasToken)
+}
+
+func (c *MaaS360Client) DistributeApp(app application.CatalogApp, target application.Target, opts application.DistributionOptions) (*application.Result, error) {
+	return application.DistributeApp(c.ServiceURL, c.BillingID, app, target, opts, c.MaasToken)
+}
+
+func (c *MaaS360Client) StopDistribution(app application.CatalogApp, target application.Target) (*application.Result, error) {
+	return application.StopDistribution(c.ServiceURL, c.BillingID, app, target, c.MaasToken)
+}
+
+func (c *MaaS360Client) GetDistributionStatus(app application.CatalogApp) (*application.DistributionStatus, error) {
+	return application.GetDistributionStatus(c.ServiceURL, c.BillingID, app, c.MaasToken)
+}
+
+func (c *MaaS360Client) UploadEnterpriseApp(fileName string, r io.Reader, size int64, meta application.AppMetadata, progress application.ProgressFunc) (*application.Result, error) {
+	return application.UploadEnterpriseApp(c.ServiceURL, c.BillingID, fileName, r, size, meta, progress, c.MaasToken)
+}
+
+func (c *MaaS360Client) UploadEnterpriseAppFile(path string, meta application.AppMetadata, progress application.ProgressFunc) (*application.Result, error) {
+	return application.UploadEnterpriseAppFile(c.ServiceURL, c.BillingID, path, meta, progress, c.MaasToken)
+}
+
+func (c *MaaS360Client) AddStoreApp(store application.Store, storeID string, meta application.AppMetadata) (*application.Result, error) {
+	return application.AddStoreApp(c.ServiceURL, c.BillingID, store, storeID, meta, c.MaasToken)
+}
+
+func (c *MaaS360Client) DeleteApp(app application.CatalogApp) (*application.Result, error) {
+	return application.DeleteApp(c.ServiceURL, c.BillingID, app, c.MaasToken)
+}
+
+func (c *MaaS360Client) DeleteAppVersion(app application.CatalogApp) (*application.Result, error) {
+	return application.DeleteAppVersion(c.ServiceURL, c.BillingID, app, c.MaasToken)
+}
+
+func (c *MaaS360Client) MarkAsPrimary(app application.CatalogApp) (*application.Result, error) {
+	return application.MarkAsPrimary(c.ServiceURL, c.BillingID, app, c.MaasToken)
+}
+
+func (c *MaaS360Client) UpgradeApp(app application.CatalogApp, fileName string, r io.Reader, size int64, opts application.UpgradeOptions, progress application.ProgressFunc) (*application.Result, error) {
+	return application.UpgradeApp(c.ServiceURL, c.BillingID, app, fileName, r, size, opts, progress, c.MaasToken)
+}
+
+func (c *MaaS360Client) GetAppDetails(app application.CatalogApp) (*application.AppDetails, error) {
+	return application.GetAppDetails(c.ServiceURL, c.BillingID, app, c.MaasToken)
+}
+
+func (c *MaaS360Client) ListInstalledDevices(appID string, version string, pageNumber int, pageSize int) ([]application.InstalledDevice, int, error) {
+	return application.ListInstalledDevices(c.ServiceURL, c.BillingID, appID, version, pageNumber, pageSize, c.MaasToken)
+}
+
+func (c *MaaS360Client) ListAllInstalledDevices(appID string, version string) ([]application.InstalledDevice, error) {
+	return application.ListAllInstalledDevices(c.ServiceURL, c.BillingID, appID, version, c.MaasToken)
+}
@@ -0,0 +1,114 @@
+// Package compliance reports on the compliance state of MaaS360 devices.
+package compliance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	httputil "maas360api/internal/http"
+	"maas360api/types"
+)
+
+// Violation is a compliance rule broken by a device.
+type Violation struct {
+	RuleName      string     `json:"ruleName"`
+	RuleSetName   string     `json:"ruleSetName"`
+	Action        string     `json:"actionExecuted"`
+	Description   string     `json:"description"`
+	ViolationTime types.Date `json:"violationTime"`
+}
+
+type violationOrViolations []Violation
+
+func (v *violationOrViolations) UnmarshalJSON(data []byte) error {
+	// Try as array
+	var arr []Violation
+	if err := json.Unmarshal(data, &arr); err == nil {
+		*v = arr
+		return nil
+	}
+	// Try as single object
+	var single Violation
+	if err := json.Unmarshal(data, &single); err == nil {
+		*v = []Violation{single}
+		return nil
+	}
+	return fmt.Errorf("violationOrViolations: cannot unmarshal %s", string(data))
+}
+
+// RuleSet describes a compliance rule set.
+type RuleSet struct {
+	Name             string             `json:"ruleSetName"`
+	Description      string             `json:"description"`
+	IsDefault        types.FlexibleBool `json:"isDefault"`
+	LastModifiedDate types.Date         `json:"lastModifiedDate"`
+}
+
+type ruleSetOrRuleSets []RuleSet
+
+func (r *ruleSetOrRuleSets) UnmarshalJSON(data []byte) error {
+	// Try as array
+	var arr []RuleSet
+	if err := json.Unmarshal(data, &arr); err == nil {
+		*r = arr
+		return nil
+	}
+	// Try as single object
+	var single RuleSet
+	if err := json.Unmarshal(data, &single); err == nil {
+		*r = []RuleSet{single}
+		return nil
+	}
+	return fmt.Errorf("ruleSetOrRuleSets: cannot unmarshal %s", string(data))
+}
+
+// GetDeviceViolations retrieves the compliance rules currently broken by a device.
+func GetDeviceViolations(serviceURL string, billingID string, deviceID string, maasToken string) ([]Violation, error) {
+	if deviceID == "" {
+		return nil, fmt.Errorf("deviceID must not be empty")
+	}
+	params := url.Values{}
+	params.Set("deviceId", deviceID)
+	body, err := doComplianceRequest(serviceURL, billingID, "ruleViolations", params, maasToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		RuleViolations struct {
+			RuleViolation violationOrViolations `json:"ruleViolation"`
+		} `json:"ruleViolations"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+	return response.RuleViolations.RuleViolation, nil
+}
+
+// ListRuleSets retrieves the compliance rule sets defined for the customer.
+func ListRuleSets(serviceURL string, billingID string, maasToken string) ([]RuleSet, error) {
+	body, err := doComplianceRequest(serviceURL, billingID, "ruleSets", nil, maasToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		RuleSets struct {
+			RuleSet ruleSetOrRuleSets `json:"ruleSet"`
+		} `json:"ruleSets"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+	return response.RuleSets.RuleSet, nil
+}
+
+// doComplianceRequest sends a GET request to a device-apis compliance endpoint, with params as
+// the query string, and returns the response body.
+func doComplianceRequest(serviceURL string, billingID string, endpoint string, params url.Values, maasToken string) ([]byte, error) {
+	if serviceURL == "" || billingID == "" || maasToken == "" {
+		return nil, fmt.Errorf("serviceURL, billingID, and maasToken must not be empty")
+	}
+	return httputil.DoFormRequest("GET", fmt.Sprintf("%s/device-apis/devices/1.0/%s/%s", serviceURL, endpoint, billingID), params, maasToken)
+}
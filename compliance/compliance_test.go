@@ -0,0 +1,37 @@
+package compliance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetDeviceViolations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/device-apis/devices/1.0/ruleViolations/1234" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("deviceId"); got != "ApplABC" {
+			t.Errorf("Expected deviceId ApplABC, got %q", got)
+		}
+		w.Write([]byte(`{"ruleViolations":{"ruleViolation":{"ruleName":"Jailbroken","ruleSetName":"Default","actionExecuted":"Alert","violationTime":"2024-05-01"}}}`))
+	}))
+	defer server.Close()
+
+	violations, err := GetDeviceViolations(server.URL, "1234", "ApplABC", "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(violations) != 1 || violations[0].RuleName != "Jailbroken" || violations[0].ViolationTime.Value.IsZero() {
+		t.Errorf("Unexpected violations: %+v", violations)
+	}
+}
+
+func TestComplianceRequestValidation(t *testing.T) {
+	if _, err := GetDeviceViolations("", "1234", "ApplABC", "token"); err == nil {
+		t.Error("Expected error for empty serviceURL")
+	}
+	if _, err := ListRuleSets("http://unused", "", "token"); err == nil {
+		t.Error("Expected error for empty billingID")
+	}
+}
@@ -0,0 +1,122 @@
+package compliance
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"maas360api/devices"
+)
+
+// Axis is one of the four compliance states tracked for each device.
+type Axis string
+
+const (
+	PolicyCompliance   Axis = "policy"
+	RuleCompliance     Axis = "rule"
+	AppCompliance      Axis = "app"
+	PasscodeCompliance Axis = "passcode"
+)
+
+// Axes lists every compliance axis.
+var Axes = []Axis{PolicyCompliance, RuleCompliance, AppCompliance, PasscodeCompliance}
+
+// State returns the device's compliance state on the axis, as reported by MaaS360.
+func (a Axis) State(device devices.Device) string {
+	switch a {
+	case PolicyCompliance:
+		return device.PolicyComplianceStatus
+	case RuleCompliance:
+		return device.RuleComplianceStatus
+	case AppCompliance:
+		return device.AppComplianceStatus
+	case PasscodeCompliance:
+		return device.PasscodeComplianceStatus
+	default:
+		return ""
+	}
+}
+
+// IsOutOfCompliance reports whether a compliance state reported by MaaS360 means the device is
+// out of compliance.
+func IsOutOfCompliance(state string) bool {
+	state = strings.ToLower(strings.TrimSpace(state))
+	return state == "ooc" || strings.Contains(state, "out of compliance") ||
+		strings.Contains(state, "not compliant") || strings.Contains(state, "non-compliant") || strings.Contains(state, "noncompliant")
+}
+
+// DeviceSummary is a device that is out of compliance on more than one axis.
+type DeviceSummary struct {
+	DeviceID string `json:"deviceId"`
+	Name     string `json:"deviceName"`
+	Platform string `json:"platform"`
+	Username string `json:"username"`
+	Axes     []Axis `json:"axes"`
+}
+
+// Summary counts devices by platform, compliance axis and state.
+type Summary struct {
+	Total int `json:"total"`
+	// Counts maps platform, then axis, then state to the number of devices.
+	Counts map[string]map[Axis]map[string]int `json:"counts"`
+	// OutOfCompliance is the number of devices out of compliance on each axis.
+	OutOfCompliance map[Axis]int `json:"outOfCompliance"`
+	// MultiAxis lists the devices out of compliance on more than one axis, worst first.
+	MultiAxis []DeviceSummary `json:"multiAxis"`
+}
+
+// SummaryReport searches every device matching the filters and summarizes their compliance.
+// The filters are those accepted by devices.SearchDevices.
+func SummaryReport(serviceURL string, billingID string, filters map[string]string, maasToken string) (*Summary, error) {
+	found, err := devices.SearchAllDevices(serviceURL, billingID, filters, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("error searching devices: %v", err)
+	}
+	return Summarize(found), nil
+}
+
+// Summarize summarizes the compliance of the given devices.
+func Summarize(found []devices.Device) *Summary {
+	summary := &Summary{
+		Total:           len(found),
+		Counts:          make(map[string]map[Axis]map[string]int),
+		OutOfCompliance: make(map[Axis]int),
+	}
+	for _, device := range found {
+		platform := device.Platform
+		if platform == "" {
+			platform = "Unknown"
+		}
+		byAxis, ok := summary.Counts[platform]
+		if !ok {
+			byAxis = make(map[Axis]map[string]int)
+			summary.Counts[platform] = byAxis
+		}
+
+		var failed []Axis
+		for _, axis := range Axes {
+			state := axis.State(device)
+			if byAxis[axis] == nil {
+				byAxis[axis] = make(map[string]int)
+			}
+			byAxis[axis][state]++
+			if IsOutOfCompliance(state) {
+				summary.OutOfCompliance[axis]++
+				failed = append(failed, axis)
+			}
+		}
+		if len(failed) > 1 {
+			summary.MultiAxis = append(summary.MultiAxis, DeviceSummary{
+				DeviceID: device.ID.String(),
+				Name:     device.Name,
+				Platform: device.Platform,
+				Username: device.Username,
+				Axes:     failed,
+			})
+		}
+	}
+	sort.SliceStable(summary.MultiAxis, func(i, j int) bool {
+		return len(summary.MultiAxis[i].Axes) > len(summary.MultiAxis[j].Axes)
+	})
+	return summary
+}
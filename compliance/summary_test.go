@@ -0,0 +1,61 @@
+package compliance
+
+import (
+	"testing"
+
+	"maas360api/devices"
+	"maas360api/types"
+)
+
+func TestSummarize(t *testing.T) {
+	device := func(id, platform, policy, rule, app, passcode string) devices.Device {
+		var deviceID types.FlexibleString
+		deviceID.Value, deviceID.IsSet = id, true
+		return devices.Device{
+			ID:                       deviceID,
+			Platform:                 platform,
+			PolicyComplianceStatus:   policy,
+			RuleComplianceStatus:     rule,
+			AppComplianceStatus:      app,
+			PasscodeComplianceStatus: passcode,
+		}
+	}
+	found := []devices.Device{
+		device("A", "iOS", "In Compliance", "In Compliance", "In Compliance", "Compliant"),
+		device("B", "iOS", "Out of Compliance", "Out of Compliance", "In Compliance", "Compliant"),
+		device("C", "Android", "Out of Compliance", "Out of Compliance", "Out of Compliance", "Not Compliant"),
+		device("D", "Android", "In Compliance", "Out of Compliance", "In Compliance", "Compliant"),
+	}
+
+	summary := Summarize(found)
+	if summary.Total != 4 {
+		t.Errorf("Expected 4 devices, got %d", summary.Total)
+	}
+	if got := summary.Counts["iOS"][PolicyCompliance]["Out of Compliance"]; got != 1 {
+		t.Errorf("Expected 1 iOS device out of policy compliance, got %d", got)
+	}
+	if got := summary.OutOfCompliance[RuleCompliance]; got != 3 {
+		t.Errorf("Expected 3 devices out of rule compliance, got %d", got)
+	}
+	if got := summary.OutOfCompliance[PasscodeCompliance]; got != 1 {
+		t.Errorf("Expected 1 device out of passcode compliance, got %d", got)
+	}
+	if len(summary.MultiAxis) != 2 || summary.MultiAxis[0].DeviceID != "C" || len(summary.MultiAxis[0].Axes) != 4 {
+		t.Errorf("Expected C then B out of compliance on several axes, got %+v", summary.MultiAxis)
+	}
+}
+
+func TestIsOutOfCompliance(t *testing.T) {
+	for state, want := range map[string]bool{
+		"Out of Compliance": true,
+		"OOC":               true,
+		"Not Compliant":     true,
+		"In Compliance":     false,
+		"Compliant":         false,
+		"":                  false,
+	} {
+		if got := IsOutOfCompliance(state); got != want {
+			t.Errorf("IsOutOfCompliance(%q): expected %v, got %v", state, want, got)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package devices
+
+import (
+	"sync"
+	"time"
+)
+
+// ActionOptions controls how PerformDeviceActionWithOptions checks and records an action.
+type ActionOptions struct {
+	// Cache, if set, is consulted before asking MaaS360 for the device's available actions.
+	Cache *ActionCache
+	// SkipCheck performs the action without checking that the device supports it.
+	SkipCheck bool
+	// RequesterWorkflow is recorded as the requester of the action.
+	RequesterWorkflow string
+}
+
+type actionCacheEntry struct {
+	actions *DeviceActionsResponse
+	expires time.Time
+}
+
+// ActionCache caches the actions available to devices for a fixed time, so that repeated
+// actions do not each need a GetDeviceActions call. It is safe for concurrent use.
+type ActionCache struct {
+	ttl        time.Duration
+	byPlatform bool
+
+	mu           sync.Mutex
+	entries      map[string]actionCacheEntry
+	platformKeys map[string]string // Device ID to platform and managed status
+}
+
+// NewActionCache returns a cache that stores the available actions of each device separately.
+func NewActionCache(ttl time.Duration) *ActionCache {
+	return &ActionCache{
+		ttl:          ttl,
+		entries:      map[string]actionCacheEntry{},
+		platformKeys: map[string]string{},
+	}
+}
+
+// NewPlatformActionCache returns a cache that shares available actions between devices with the
+// same platform and managed status. Devices must be registered with AddDevices to share entries;
+// unregistered devices are cached individually.
+func NewPlatformActionCache(ttl time.Duration) *ActionCache {
+	cache := NewActionCache(ttl)
+	cache.byPlatform = true
+	return cache
+}
+
+// AddDevices records the platform and managed status of devices, typically from SearchDevices results.
+func (c *ActionCache) AddDevices(devices ...Device) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, device := range devices {
+		if id := device.ID.String(); id != "" {
+			c.platformKeys[id] = device.Platform + "|" + device.ManagedStatus
+		}
+	}
+}
+
+// Clear removes all cached actions.
+func (c *ActionCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]actionCacheEntry{}
+}
+
+// key returns the cache key for a device. Callers must hold c.mu.
+func (c *ActionCache) key(deviceID string) string {
+	if c.byPlatform {
+		if key, ok := c.platformKeys[deviceID]; ok {
+			return "platform:" + key
+		}
+	}
+	return "device:" + deviceID
+}
+
+func (c *ActionCache) get(deviceID string) (*DeviceActionsResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := c.key(deviceID)
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.actions, true
+}
+
+func (c *ActionCache) put(deviceID string, actions *DeviceActionsResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[c.key(deviceID)] = actionCacheEntry{actions: actions, expires: time.Now().Add(c.ttl)}
+}
+
+// getAvailableActions returns the device's available actions from the cache, if given and
+// still fresh, or from MaaS360.
+func getAvailableActions(serviceURL string, billingID string, deviceID string, cache *ActionCache, maasToken string) (*DeviceActionsResponse, error) {
+	if cache != nil {
+		if actions, ok := cache.get(deviceID); ok {
+			return actions, nil
+		}
+	}
+	actions, err := GetDeviceActions(serviceURL, billingID, deviceID, maasToken)
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		cache.put(deviceID, actions)
+	}
+	return actions, nil
+}
@@ -0,0 +1,99 @@
+package devices
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"maas360api/types"
+)
+
+func flexibleID(id string) types.FlexibleString {
+	return types.FlexibleString{Value: id, IsSet: true}
+}
+
+func newActionServer(t *testing.T, listCalls *int32, actionCalls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/device-apis/devices/1.0/deviceActions/"):
+			atomic.AddInt32(listCalls, 1)
+			w.Write([]byte(`{"deviceActions":{"deviceAction":[{"actionId":"MDM_LOCK","actionName":"Lock Device"},{"actionId":"MDM_BUZZ","actionName":"Buzz Device"}]}}`))
+		case strings.HasPrefix(r.URL.Path, "/action-apis/actions/1.0/customer/"):
+			atomic.AddInt32(actionCalls, 1)
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestPerformDeviceAction_Cache(t *testing.T) {
+	var listCalls, actionCalls int32
+	server := newActionServer(t, &listCalls, &actionCalls)
+	defer server.Close()
+
+	cache := NewPlatformActionCache(time.Minute)
+	cache.AddDevices(
+		Device{ID: flexibleID("1"), Platform: "iOS", ManagedStatus: "Enrolled"},
+		Device{ID: flexibleID("2"), Platform: "iOS", ManagedStatus: "Enrolled"},
+	)
+	opts := ActionOptions{Cache: cache}
+
+	for _, deviceID := range []string{"1", "2", "1"} {
+		if err := PerformDeviceActionWithOptions(server.URL, "1234", deviceID, "MDM_LOCK", nil, opts, "token"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if listCalls != 1 {
+		t.Errorf("Expected 1 available actions request, got %d", listCalls)
+	}
+	if actionCalls != 3 {
+		t.Errorf("Expected 3 action requests, got %d", actionCalls)
+	}
+
+	cache.Clear()
+	if err := PerformDeviceActionWithOptions(server.URL, "1234", "3", "MDM_LOCK", nil, opts, "token"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if listCalls != 2 {
+		t.Errorf("Expected unregistered device to fetch its own actions, got %d requests", listCalls)
+	}
+}
+
+func TestPerformDeviceAction_SkipCheck(t *testing.T) {
+	var listCalls, actionCalls int32
+	server := newActionServer(t, &listCalls, &actionCalls)
+	defer server.Close()
+
+	err := PerformDeviceActionWithOptions(server.URL, "1234", "1", "MDM_LOCK", nil, ActionOptions{SkipCheck: true}, "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if listCalls != 0 || actionCalls != 1 {
+		t.Errorf("Expected no available actions request and 1 action request, got %d and %d", listCalls, actionCalls)
+	}
+}
+
+func TestPerformDeviceAction_Unsupported(t *testing.T) {
+	var listCalls, actionCalls int32
+	server := newActionServer(t, &listCalls, &actionCalls)
+	defer server.Close()
+
+	err := PerformDeviceAction(server.URL, "1234", "1", "MDM_WIPE", nil, "token")
+
+	var unsupported *UnsupportedActionError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("Expected UnsupportedActionError, got %v", err)
+	}
+	if strings.Join(unsupported.Available, ",") != "MDM_LOCK,MDM_BUZZ" {
+		t.Errorf("Expected available actions MDM_LOCK,MDM_BUZZ, got %v", unsupported.Available)
+	}
+	if actionCalls != 0 {
+		t.Errorf("Expected no action request, got %d", actionCalls)
+	}
+}
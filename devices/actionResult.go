@@ -0,0 +1,100 @@
+package devices
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"maas360api/internal/constants"
+	httputil "maas360api/internal/http"
+	"maas360api/types"
+)
+
+// ActionResult is the outcome of a device action accepted by MaaS360.
+type ActionResult struct {
+	DeviceID          string // MaaS360 device ID the action was issued for
+	ActionID          string // MaaS360 action ID, used to track the action's progress; empty if none was returned
+	Status            int    // MaaS360 action status, 0 on success
+	Description       string // Description returned by MaaS360
+	TemporaryPasscode string // Passcode generated by ResetPasscode, empty for other actions
+}
+
+// actionResponseBody is the body of a device action response.
+type actionResponseBody struct {
+	Maas360DeviceID string            `json:"maas360DeviceID"`
+	ActionStatus    int               `json:"actionStatus"`
+	ActionID        types.FlexibleInt `json:"actionID"`
+	Description     string            `json:"description"`
+	TempPasscode    string            `json:"tempPasscode"`
+}
+
+// decodeActionResponse parses a device action response, which MaaS360 returns either bare or
+// wrapped in an "actionResponse" object. A non-zero action status is reported as an error, as is
+// a response without a device ID, since MaaS360 did not confirm the action. Some actions, such as
+// sendMessage, are confirmed without an action ID; their result has an empty ActionID.
+func decodeActionResponse(body []byte) (*ActionResult, error) {
+	var wrapped struct {
+		ActionResponse *actionResponseBody `json:"actionResponse"`
+	}
+	if err := json.Unmarshal(body, &wrapped); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+	response := wrapped.ActionResponse
+	if response == nil {
+		response = &actionResponseBody{}
+		if err := json.Unmarshal(body, response); err != nil {
+			return nil, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+		}
+	}
+	if response.ActionStatus != 0 {
+		return nil, fmt.Errorf("action failed with status %d: %s", response.ActionStatus, response.Description)
+	}
+	if response.Maas360DeviceID == "" {
+		return nil, fmt.Errorf("response has no device ID; body: %s", string(body))
+	}
+	return &ActionResult{
+		DeviceID:          response.Maas360DeviceID,
+		ActionID:          response.ActionID.String(),
+		Status:            response.ActionStatus,
+		Description:       response.Description,
+		TemporaryPasscode: response.TempPasscode,
+	}, nil
+}
+
+// doDeviceAction posts to a device-apis action endpoint, sending params as a form body,
+// and decodes the action response.
+func doDeviceAction(serviceURL string, billingID string, endpoint string, deviceID string, params url.Values, maasToken string) (*ActionResult, error) {
+	if serviceURL == "" || billingID == "" || deviceID == "" || maasToken == "" {
+		return nil, fmt.Errorf("serviceURL, billingID, deviceID, and maasToken must not be empty")
+	}
+
+	actionURL := fmt.Sprintf("%s/device-apis/devices/1.0/%s/%s?deviceId=%s", serviceURL, endpoint, billingID, url.QueryEscape(deviceID))
+	result, err := postDeviceAction(actionURL, params, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed for device %s: %v", endpoint, deviceID, err)
+	}
+	return result, nil
+}
+
+// postDeviceAction sends a device action request to actionURL and decodes the action response.
+func postDeviceAction(actionURL string, params url.Values, maasToken string) (*ActionResult, error) {
+	resp, err := httputil.DoMaaSRequest(httputil.RequestOptions{
+		Method:      "POST",
+		URL:         actionURL,
+		Body:        strings.NewReader(params.Encode()),
+		ContentType: constants.ContentTypeForm,
+		MaaSToken:   maasToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %v", err)
+	}
+	return decodeActionResponse(body)
+}
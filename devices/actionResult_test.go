@@ -0,0 +1,240 @@
+package devices
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDecodeActionResponse(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		wantErr      bool
+		wantDeviceID string
+		wantActionID string
+	}{
+		{
+			name:         "wrapped success",
+			body:         `{"actionResponse":{"maas360DeviceID":"ApplABC","actionStatus":0,"actionID":"12345","description":"Message sent"}}`,
+			wantDeviceID: "ApplABC",
+			wantActionID: "12345",
+		},
+		{
+			name:         "bare success",
+			body:         `{"maas360DeviceId":"ApplABC","actionStatus":0,"actionID":678,"description":"Lock scheduled"}`,
+			wantDeviceID: "ApplABC",
+			wantActionID: "678",
+		},
+		{
+			name:    "wrapped failure",
+			body:    `{"actionResponse":{"maas360DeviceID":"ApplABC","actionStatus":1,"description":"Device not found"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "bare failure",
+			body:    `{"maas360DeviceId":"ApplABC","actionStatus":1,"description":"Action not supported"}`,
+			wantErr: true,
+		},
+		{
+			name:    "empty object",
+			body:    `{}`,
+			wantErr: true,
+		},
+		{
+			name:         "missing action ID",
+			body:         `{"actionResponse":{"maas360DeviceID":"ApplABC","actionStatus":0,"description":"Message sent"}}`,
+			wantDeviceID: "ApplABC",
+		},
+		{
+			name:    "invalid JSON",
+			body:    `not json`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := decodeActionResponse([]byte(tt.body))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Expected error, got result %+v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result.DeviceID != tt.wantDeviceID {
+				t.Errorf("Expected device ID %s, got %s", tt.wantDeviceID, result.DeviceID)
+			}
+			if result.ActionID != tt.wantActionID {
+				t.Errorf("Expected action ID %s, got %s", tt.wantActionID, result.ActionID)
+			}
+		})
+	}
+}
+
+func TestDeviceActions(t *testing.T) {
+	actions := []struct {
+		name     string
+		endpoint string
+		call     func(serviceURL string) (*ActionResult, error)
+	}{
+		{
+			name:     "lock",
+			endpoint: "/device-apis/devices/1.0/lockDevice/1234",
+			call: func(serviceURL string) (*ActionResult, error) {
+				return LockDevice(serviceURL, "1234", "ApplABC", "token")
+			},
+		},
+		{
+			name:     "hide",
+			endpoint: "/device-apis/devices/1.0/hideDevice/1234",
+			call: func(serviceURL string) (*ActionResult, error) {
+				return HideDevice(serviceURL, "1234", "ApplABC", "token")
+			},
+		},
+		{
+			name:     "message",
+			endpoint: "/device-apis/devices/1.0/sendMessage/1234",
+			call: func(serviceURL string) (*ActionResult, error) {
+				return SendMessage(serviceURL, "1234", "ApplABC", "Title", "Hello", "token")
+			},
+		},
+		{
+			name:     "buzz",
+			endpoint: "/device-apis/devices/1.0/buzzDevice/1234",
+			call: func(serviceURL string) (*ActionResult, error) {
+				return BuzzDevice(serviceURL, "1234", "ApplABC", "token")
+			},
+		},
+		{
+			name:     "refresh",
+			endpoint: "/device-apis/devices/1.0/refreshDeviceInformation/1234",
+			call: func(serviceURL string) (*ActionResult, error) {
+				return RefreshDeviceInformation(serviceURL, "1234", "ApplABC", "token")
+			},
+		},
+		{
+			name:     "reset passcode",
+			endpoint: "/device-apis/devices/1.0/resetDevicePasscode/1234",
+			call: func(serviceURL string) (*ActionResult, error) {
+				return ResetPasscode(serviceURL, "1234", "ApplABC", "token")
+			},
+		},
+		{
+			name:     "clear passcode",
+			endpoint: "/device-apis/devices/1.0/clearPasscode/1234",
+			call: func(serviceURL string) (*ActionResult, error) {
+				return ClearPasscode(serviceURL, "1234", "ApplABC", "token")
+			},
+		},
+		{
+			name:     "stop mailbox sync",
+			endpoint: "/device-apis/devices/1.0/blockDeviceMessagingSystem/1234",
+			call: func(serviceURL string) (*ActionResult, error) {
+				return StopMailboxSync(serviceURL, "1234", "ApplABC", "token")
+			},
+		},
+		{
+			name:     "start mailbox sync",
+			endpoint: "/device-apis/devices/1.0/approveDeviceMessagingSystem/1234",
+			call: func(serviceURL string) (*ActionResult, error) {
+				return StartMailboxSync(serviceURL, "1234", "ApplABC", "token")
+			},
+		},
+		{
+			name:     "rename",
+			endpoint: "/device-apis/devices/1.0/setDeviceName/1234",
+			call: func(serviceURL string) (*ActionResult, error) {
+				return RenameDevice(serviceURL, "1234", "ApplABC", "Front desk iPad", "token")
+			},
+		},
+		{
+			name:     "ownership",
+			endpoint: "/device-apis/devices/1.0/setDeviceOwnership/1234",
+			call: func(serviceURL string) (*ActionResult, error) {
+				return SetDeviceOwnership(serviceURL, "1234", "ApplABC", CorporateShared, "token")
+			},
+		},
+	}
+	responses := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			status: http.StatusOK,
+			body:   `{"actionResponse":{"maas360DeviceID":"ApplABC","actionStatus":0,"actionID":"42","description":"OK"}}`,
+		},
+		{
+			name:    "failure status in body",
+			status:  http.StatusOK,
+			body:    `{"actionResponse":{"maas360DeviceID":"ApplABC","actionStatus":1,"description":"Failed"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "HTTP error",
+			status:  http.StatusInternalServerError,
+			body:    `{}`,
+			wantErr: true,
+		},
+	}
+
+	for _, action := range actions {
+		for _, response := range responses {
+			t.Run(action.name+"/"+response.name, func(t *testing.T) {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.Method != http.MethodPost {
+						t.Errorf("Expected POST, got %s", r.Method)
+					}
+					if r.URL.Path != action.endpoint {
+						t.Errorf("Expected path %s, got %s", action.endpoint, r.URL.Path)
+					}
+					if !strings.Contains(r.Header.Get("Authorization"), "token") {
+						t.Errorf("Expected MaaS token in Authorization header, got %q", r.Header.Get("Authorization"))
+					}
+					w.WriteHeader(response.status)
+					w.Write([]byte(response.body))
+				}))
+				defer server.Close()
+
+				result, err := action.call(server.URL)
+				if response.wantErr {
+					if err == nil {
+						t.Fatalf("Expected error, got result %+v", result)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if result.ActionID != "42" {
+					t.Errorf("Expected action ID 42, got %s", result.ActionID)
+				}
+				if result.DeviceID != "ApplABC" {
+					t.Errorf("Expected device ID ApplABC, got %s", result.DeviceID)
+				}
+			})
+		}
+	}
+}
+
+func TestResetPasscode_TemporaryPasscode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"actionResponse":{"maas360DeviceID":"AndrABC","actionStatus":0,"actionID":"12","tempPasscode":"4821"}}`))
+	}))
+	defer server.Close()
+
+	result, err := ResetPasscode(server.URL, "1234", "AndrABC", "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.TemporaryPasscode != "4821" {
+		t.Errorf("Expected temporary passcode 4821, got %q", result.TemporaryPasscode)
+	}
+}
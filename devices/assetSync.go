@@ -0,0 +1,179 @@
+package devices
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// AssetSyncItem is a device whose identity differs from its asset record.
+type AssetSyncItem struct {
+	SerialNumber string
+	DeviceID     string
+	DeviceName   string
+	Changes      []FieldChange
+}
+
+// AssetSyncPlan lists the changes needed to bring devices in line with a set of asset records.
+type AssetSyncPlan struct {
+	Items     []AssetSyncItem
+	Unchanged int      // Matched devices that already match their record
+	Unmatched []string // Serial numbers with no matching device
+}
+
+// AssetSyncResult is the outcome of applying one item of a plan.
+type AssetSyncResult struct {
+	Item   AssetSyncItem
+	Update *IdentityUpdate
+	Err    error
+}
+
+// PlanAssetSync reads asset records from CSV and compares them with the identity of the matching
+// devices, without changing anything. The CSV must have a header row with a "serialNumber" column;
+// the other columns are named after the DeviceIdentity JSON fields, for example "poNumber" or
+// "warrantyExpirationDate". Empty cells leave the field unchanged.
+func PlanAssetSync(serviceURL string, billingID string, records io.Reader, maasToken string) (*AssetSyncPlan, error) {
+	patches, err := parseAssetRecords(records)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := SearchAllDevices(serviceURL, billingID, nil, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("error listing devices: %v", err)
+	}
+	bySerial := make(map[string]Device, len(found))
+	for _, device := range found {
+		if device.SerialNumber != "" {
+			bySerial[strings.ToUpper(device.SerialNumber)] = device
+		}
+	}
+
+	plan := &AssetSyncPlan{}
+	for _, record := range patches {
+		device, ok := bySerial[strings.ToUpper(record.serialNumber)]
+		if !ok {
+			plan.Unmatched = append(plan.Unmatched, record.serialNumber)
+			continue
+		}
+		deviceID := device.ID.String()
+		current, err := GetDeviceAttributes(serviceURL, billingID, deviceID, maasToken)
+		if err != nil {
+			return nil, fmt.Errorf("error getting identity of device %s: %v", deviceID, err)
+		}
+		changes := record.patch.Changes(*current)
+		if len(changes) == 0 {
+			plan.Unchanged++
+			continue
+		}
+		plan.Items = append(plan.Items, AssetSyncItem{
+			SerialNumber: record.serialNumber,
+			DeviceID:     deviceID,
+			DeviceName:   device.Name,
+			Changes:      changes,
+		})
+	}
+	return plan, nil
+}
+
+// WritePreview writes a human-readable diff of the plan.
+func (p *AssetSyncPlan) WritePreview(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SERIAL\tDEVICE\tFIELD\tCURRENT\tNEW")
+	for _, item := range p.Items {
+		for _, change := range item.Changes {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", item.SerialNumber, item.DeviceName, change.Field, change.Old, change.New)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%d devices to update, %d unchanged, %d serial numbers not found\n", len(p.Items), p.Unchanged, len(p.Unmatched))
+	return err
+}
+
+// ApplyAssetSync sends the changes of a plan, continuing past individual failures.
+func ApplyAssetSync(serviceURL string, billingID string, plan *AssetSyncPlan, maasToken string) []AssetSyncResult {
+	results := make([]AssetSyncResult, 0, len(plan.Items))
+	for _, item := range plan.Items {
+		update, err := updateIdentityFields(serviceURL, billingID, item.DeviceID, item.Changes, maasToken)
+		results = append(results, AssetSyncResult{Item: item, Update: update, Err: err})
+	}
+	return results
+}
+
+type assetRecord struct {
+	serialNumber string
+	patch        DeviceIdentityPatch
+}
+
+// parseAssetRecords reads CSV asset records into identity patches.
+func parseAssetRecords(r io.Reader) ([]assetRecord, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading CSV header: %v", err)
+	}
+
+	serialColumn := -1
+	columns := make(map[int]string, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if strings.EqualFold(name, "serialNumber") {
+			serialColumn = i
+			continue
+		}
+		if (&DeviceIdentityPatch{}).field(name) == nil {
+			return nil, fmt.Errorf("CSV column %s is not a device identity field", name)
+		}
+		columns[i] = name
+	}
+	if serialColumn < 0 {
+		return nil, fmt.Errorf("CSV has no serialNumber column")
+	}
+
+	var records []assetRecord
+	seen := map[string]int{}
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading CSV line %d: %v", line, err)
+		}
+		record := assetRecord{serialNumber: strings.TrimSpace(row[serialColumn])}
+		if record.serialNumber == "" {
+			return nil, fmt.Errorf("CSV line %d has no serial number", line)
+		}
+		key := strings.ToUpper(record.serialNumber)
+		if first, ok := seen[key]; ok {
+			return nil, fmt.Errorf("CSV line %d repeats serial number %s from line %d", line, record.serialNumber, first)
+		}
+		seen[key] = line
+		for i, name := range columns {
+			value := strings.TrimSpace(row[i])
+			if value == "" {
+				continue
+			}
+			*record.patch.field(name) = &value
+		}
+		if err := record.patch.normalize(); err != nil {
+			return nil, fmt.Errorf("CSV line %d: %v", line, err)
+		}
+		records = append(records, record)
+	}
+}
+
+// field returns the patch field with the given JSON name, compared case-insensitively.
+func (p *DeviceIdentityPatch) field(name string) **string {
+	for _, field := range p.fields(DeviceIdentity{}) {
+		if strings.EqualFold(field.name, name) {
+			return field.patch
+		}
+	}
+	return nil
+}
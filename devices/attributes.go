@@ -0,0 +1,174 @@
+package devices
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"maas360api/types"
+)
+
+// Attributes is a list of key/value attributes, as returned for hardware inventory,
+// network information and installed software, with typed lookups.
+type Attributes []DeviceAttribute
+
+// Get returns the attribute with the given key, compared case-insensitively.
+// If there is none it returns an empty attribute whose typed accessors report an error.
+func (a Attributes) Get(key string) DeviceAttribute {
+	for _, attr := range a {
+		if strings.EqualFold(attr.AttributeKey, key) {
+			return attr
+		}
+	}
+	return DeviceAttribute{AttributeKey: key}
+}
+
+// Has reports whether an attribute with the given key is present and has a value.
+func (a Attributes) Has(key string) bool {
+	return a.Get(key).AttributeValue.IsSet
+}
+
+// first returns the first of keys that is present, for attributes whose name varies by platform.
+func (a Attributes) first(keys ...string) DeviceAttribute {
+	for _, key := range keys {
+		if attr := a.Get(key); attr.AttributeValue.IsSet {
+			return attr
+		}
+	}
+	return DeviceAttribute{}
+}
+
+// Map returns the attributes as a map of key to string value.
+func (a Attributes) Map() map[string]string {
+	m := make(map[string]string, len(a))
+	for _, attr := range a {
+		m[attr.AttributeKey] = attr.AttributeValue.String()
+	}
+	return m
+}
+
+// AsString returns the attribute value as text, empty if not set.
+func (d DeviceAttribute) AsString() string {
+	return d.AttributeValue.String()
+}
+
+// AsInt returns the attribute value as an integer.
+func (d DeviceAttribute) AsInt() (int64, error) {
+	if !d.AttributeValue.IsSet {
+		return 0, fmt.Errorf("attribute %s is not set", d.AttributeKey)
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(d.AttributeValue.String()), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("attribute %s is not an integer: %q", d.AttributeKey, d.AttributeValue)
+	}
+	return n, nil
+}
+
+// AsFloat returns the attribute value as a number, ignoring a trailing percent sign.
+func (d DeviceAttribute) AsFloat() (float64, error) {
+	if !d.AttributeValue.IsSet {
+		return 0, fmt.Errorf("attribute %s is not set", d.AttributeKey)
+	}
+	text := strings.TrimSuffix(strings.TrimSpace(d.AttributeValue.String()), "%")
+	f, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil {
+		return 0, fmt.Errorf("attribute %s is not a number: %q", d.AttributeKey, d.AttributeValue)
+	}
+	return f, nil
+}
+
+// AsBool returns the attribute value as a boolean, accepting values such as "Yes", "true" and 1.
+func (d DeviceAttribute) AsBool() (bool, error) {
+	if !d.AttributeValue.IsSet {
+		return false, fmt.Errorf("attribute %s is not set", d.AttributeKey)
+	}
+	b, ok := types.ParseFlexibleBool(d.AttributeValue.String())
+	if !ok {
+		return false, fmt.Errorf("attribute %s is not a boolean: %q", d.AttributeKey, d.AttributeValue)
+	}
+	return b, nil
+}
+
+// byteUnits maps size suffixes to their multiplier. MaaS360 reports sizes in binary units.
+var byteUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"kb":  1 << 10,
+	"kib": 1 << 10,
+	"mb":  1 << 20,
+	"mib": 1 << 20,
+	"gb":  1 << 30,
+	"gib": 1 << 30,
+	"tb":  1 << 40,
+	"tib": 1 << 40,
+}
+
+// AsBytes returns a size such as "3.5 GB", "2048 MB" or "1048576" as a number of bytes.
+func (d DeviceAttribute) AsBytes() (int64, error) {
+	if !d.AttributeValue.IsSet {
+		return 0, fmt.Errorf("attribute %s is not set", d.AttributeKey)
+	}
+	text := strings.TrimSpace(d.AttributeValue.String())
+	end := 0
+	for end < len(text) && (text[end] >= '0' && text[end] <= '9' || text[end] == '.' || text[end] == ',') {
+		end++
+	}
+	number := strings.ReplaceAll(text[:end], ",", "")
+	unit := strings.ToLower(strings.TrimSpace(text[end:]))
+	multiplier, ok := byteUnits[unit]
+	if number == "" || !ok {
+		return 0, fmt.Errorf("attribute %s is not a size: %q", d.AttributeKey, d.AttributeValue)
+	}
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("attribute %s is not a size: %q", d.AttributeKey, d.AttributeValue)
+	}
+	return int64(f * multiplier), nil
+}
+
+// HardwareSummary is a projection of the commonly used hardware inventory attributes.
+// Values that are not reported are left at zero.
+type HardwareSummary struct {
+	RAM          int64   // Total memory in bytes
+	Storage      int64   // Total internal storage in bytes
+	FreeStorage  int64   // Available internal storage in bytes
+	Battery      float64 // Battery level in percent
+	CPU          string  // Processor description
+	SerialNumber string
+}
+
+// HardwareSummary decodes the well-known hardware inventory attributes.
+// The keys are the attribute names in the deviceAttributes list returned by
+// /device-apis/devices/1.0/hardwareInventory. MaaS360 labels the same value differently
+// depending on the platform, so each field is read from the first of its candidate keys
+// that is present.
+func (a Attributes) HardwareSummary() HardwareSummary {
+	var summary HardwareSummary
+	summary.RAM, _ = a.first("Total RAM", "RAM", "Total Memory", "Physical Memory").AsBytes()
+	summary.Storage, _ = a.first("Total Internal Storage", "Total Storage", "Device Capacity", "Total Disk Space").AsBytes()
+	summary.FreeStorage, _ = a.first("Free Internal Storage", "Available Internal Storage", "Available Storage", "Free Disk Space").AsBytes()
+	summary.Battery, _ = a.first("Battery Level", "Battery", "Battery Status").AsFloat()
+	summary.CPU = a.first("Processor", "Processor Name", "CPU", "Processor Type").AsString()
+	summary.SerialNumber = a.first("Serial Number", "Platform Serial Number").AsString()
+	return summary
+}
+
+// NetworkSummary is a projection of the commonly used network information attributes.
+type NetworkSummary struct {
+	IP      string
+	SSID    string
+	Carrier string
+	Roaming bool
+}
+
+// NetworkSummary decodes the well-known network information attributes.
+// The keys are the attribute names returned by /device-apis/devices/1.0/mdNetworkInformation,
+// with the platform-specific alternatives tried in order as for HardwareSummary.
+func (a Attributes) NetworkSummary() NetworkSummary {
+	var summary NetworkSummary
+	summary.IP = a.first("IP Address", "Wi-Fi IP Address", "IPv4 Address", "Device IP Address").AsString()
+	summary.SSID = a.first("SSID", "Current SSID", "Wi-Fi SSID", "Network SSID").AsString()
+	summary.Carrier = a.first("Current Carrier Network", "Carrier", "Home Carrier Network", "Operator").AsString()
+	summary.Roaming, _ = a.first("Roaming", "Is Roaming", "Data Roaming", "Roaming Status").AsBool()
+	return summary
+}
@@ -0,0 +1,71 @@
+package devices
+
+import (
+	"encoding/json"
+	"testing"
+
+	"maas360api/types"
+)
+
+const hardwareInventoryJSON = `{"deviceHardware":{"maas360DeviceID":"ApplABC","deviceAttributes":{"deviceAttribute":[
+	{"key":"Total RAM","type":"String","value":"3.5 GB"},
+	{"key":"Total Internal Storage","type":"String","value":"128 GB"},
+	{"key":"Free Internal Storage","type":"String","value":"2,048 MB"},
+	{"key":"Battery Level","type":"String","value":"87%"},
+	{"key":"Processor","type":"String","value":"Apple A15"},
+	{"key":"Cellular Technology","type":"Integer","value":2},
+	{"key":"Is Supervised","type":"String","value":"Yes"}
+]}}}`
+
+func TestHardwareInventory_Get(t *testing.T) {
+	var inventory HardwareInventoryResponse
+	if err := json.Unmarshal([]byte(hardwareInventoryJSON), &inventory); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ram, err := inventory.Get("total ram").AsBytes()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ram != 3758096384 {
+		t.Errorf("Expected 3.5 GB in bytes, got %d", ram)
+	}
+	if n, err := inventory.Get("Cellular Technology").AsInt(); err != nil || n != 2 {
+		t.Errorf("Expected 2, got %d (%v)", n, err)
+	}
+	if b, err := inventory.Get("Is Supervised").AsBool(); err != nil || !b {
+		t.Errorf("Expected true, got %v (%v)", b, err)
+	}
+	if _, err := inventory.Get("Missing").AsInt(); err == nil {
+		t.Error("Expected error for missing attribute")
+	}
+	if _, err := inventory.Get("Processor").AsBytes(); err == nil {
+		t.Error("Expected error for non-size attribute")
+	}
+
+	summary := inventory.Summary()
+	expected := HardwareSummary{
+		RAM:         3758096384,
+		Storage:     128 << 30,
+		FreeStorage: 2048 << 20,
+		Battery:     87,
+		CPU:         "Apple A15",
+	}
+	if summary != expected {
+		t.Errorf("Expected %+v, got %+v", expected, summary)
+	}
+}
+
+func TestAttributes_NetworkSummary(t *testing.T) {
+	attrs := Attributes{
+		{AttributeKey: "Wi-Fi IP Address", AttributeValue: types.FlexibleString{Value: "10.0.0.12", IsSet: true}},
+		{AttributeKey: "Current SSID", AttributeValue: types.FlexibleString{Value: "Corp-WiFi", IsSet: true}},
+		{AttributeKey: "Current Carrier Network", AttributeValue: types.FlexibleString{Value: "Verizon", IsSet: true}},
+		{AttributeKey: "Roaming", AttributeValue: types.FlexibleString{Value: "No", IsSet: true}},
+	}
+
+	expected := NetworkSummary{IP: "10.0.0.12", SSID: "Corp-WiFi", Carrier: "Verizon", Roaming: false}
+	if summary := attrs.NetworkSummary(); summary != expected {
+		t.Errorf("Expected %+v, got %+v", expected, summary)
+	}
+}
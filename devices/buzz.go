@@ -0,0 +1,6 @@
+package devices
+
+// BuzzDevice makes a device ring so it can be found nearby.
+func BuzzDevice(serviceURL string, billingID string, deviceID string, maasToken string) (*ActionResult, error) {
+	return doDeviceAction(serviceURL, billingID, "buzzDevice", deviceID, nil, maasToken)
+}
@@ -0,0 +1,25 @@
+package devices
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// ChangeMDMPolicy assigns an MDM policy to a device.
+func ChangeMDMPolicy(serviceURL string, billingID string, deviceID string, policyName string, maasToken string) (*ActionResult, error) {
+	return changePolicy(serviceURL, billingID, "changeDevicePolicy", deviceID, policyName, maasToken)
+}
+
+// ChangePersonaPolicy assigns a persona (WorkPlace) policy to a device.
+func ChangePersonaPolicy(serviceURL string, billingID string, deviceID string, policyName string, maasToken string) (*ActionResult, error) {
+	return changePolicy(serviceURL, billingID, "changePersonaPolicy", deviceID, policyName, maasToken)
+}
+
+func changePolicy(serviceURL string, billingID string, endpoint string, deviceID string, policyName string, maasToken string) (*ActionResult, error) {
+	if policyName == "" {
+		return nil, fmt.Errorf("policyName must not be empty")
+	}
+	params := url.Values{}
+	params.Set("policyName", policyName)
+	return doDeviceAction(serviceURL, billingID, endpoint, deviceID, params, maasToken)
+}
@@ -0,0 +1,180 @@
+package devices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	httputil "maas360api/internal/http"
+	"maas360api/types"
+)
+
+// CustomAttributeType is the data type of a custom attribute defined for the customer.
+type CustomAttributeType string
+
+const (
+	CustomAttributeText    CustomAttributeType = "Text"
+	CustomAttributeNumber  CustomAttributeType = "Number"
+	CustomAttributeDate    CustomAttributeType = "Date"
+	CustomAttributeBoolean CustomAttributeType = "Boolean"
+)
+
+// CustomAttributeDefinition describes a custom attribute defined for the customer.
+type CustomAttributeDefinition struct {
+	Name string              `json:"attributeName"`
+	Type CustomAttributeType `json:"attributeType"`
+}
+
+// CustomAttributeDateLayout is the layout date values are sent in.
+const CustomAttributeDateLayout = "2006-01-02"
+
+// Validate checks that value can be stored in the attribute.
+func (d CustomAttributeDefinition) Validate(value string) error {
+	_, err := d.Normalize(value)
+	return err
+}
+
+// Normalize checks that value can be stored in the attribute and returns it in the form sent to
+// MaaS360: numbers without surrounding space, dates in CustomAttributeDateLayout and booleans as
+// "Yes" or "No". Text is returned unchanged.
+func (d CustomAttributeDefinition) Normalize(value string) (string, error) {
+	switch strings.ToLower(string(d.Type)) {
+	case "text", "string":
+		return value, nil
+	case "number", "numeric", "integer":
+		number := strings.TrimSpace(value)
+		if _, err := strconv.ParseFloat(number, 64); err != nil {
+			return "", fmt.Errorf("custom attribute %s expects a number, got %q", d.Name, value)
+		}
+		return number, nil
+	case "date":
+		date, err := types.ParseDate(value)
+		if err != nil {
+			return "", fmt.Errorf("custom attribute %s expects a date, got %q", d.Name, value)
+		}
+		return date.Format(CustomAttributeDateLayout), nil
+	case "boolean":
+		b, ok := types.ParseFlexibleBool(value)
+		if !ok {
+			return "", fmt.Errorf("custom attribute %s expects a boolean, got %q", d.Name, value)
+		}
+		if b {
+			return "Yes", nil
+		}
+		return "No", nil
+	default:
+		return "", fmt.Errorf("custom attribute %s has unsupported type %s", d.Name, d.Type)
+	}
+}
+
+// CustomAttributeUpdate is a single value to set in a batch.
+type CustomAttributeUpdate struct {
+	DeviceID string
+	Name     string
+	Value    string
+}
+
+// CustomAttributeResult is the outcome of one update in a batch.
+type CustomAttributeResult struct {
+	Update CustomAttributeUpdate
+	Result *ActionResult
+	Err    error
+}
+
+type customAttributeDefinitions []CustomAttributeDefinition
+
+func (c *customAttributeDefinitions) UnmarshalJSON(data []byte) error {
+	// Try as array
+	var arr []CustomAttributeDefinition
+	if err := json.Unmarshal(data, &arr); err == nil {
+		*c = arr
+		return nil
+	}
+	// Try as single object
+	var single CustomAttributeDefinition
+	if err := json.Unmarshal(data, &single); err == nil {
+		*c = []CustomAttributeDefinition{single}
+		return nil
+	}
+	return fmt.Errorf("customAttributeDefinitions: cannot unmarshal %s", string(data))
+}
+
+type customAttributeDefinitionsResponse struct {
+	CustomAttributes struct {
+		CustomAttribute customAttributeDefinitions `json:"customAttribute"`
+	} `json:"customAttributes"`
+}
+
+// ListCustomAttributeDefinitions retrieves the custom attributes defined for the customer.
+func ListCustomAttributeDefinitions(serviceURL string, billingID string, maasToken string) ([]CustomAttributeDefinition, error) {
+	if serviceURL == "" || billingID == "" || maasToken == "" {
+		return nil, fmt.Errorf("serviceURL, billingID, and maasToken must not be empty")
+	}
+
+	definitionsURL := fmt.Sprintf("%s/device-apis/devices/1.0/customAttributes/%s", serviceURL, billingID)
+	body, err := httputil.DoFormRequest("GET", definitionsURL, nil, maasToken)
+	if err != nil {
+		return nil, err
+	}
+	var response customAttributeDefinitionsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+	return response.CustomAttributes.CustomAttribute, nil
+}
+
+// SetCustomAttribute sets a custom attribute on a device after validating the value against
+// the attribute's definition.
+func SetCustomAttribute(serviceURL string, billingID string, deviceID string, name string, value string, maasToken string) (*ActionResult, error) {
+	results, err := SetCustomAttributes(serviceURL, billingID, []CustomAttributeUpdate{{DeviceID: deviceID, Name: name, Value: value}}, maasToken)
+	if err != nil {
+		return nil, err
+	}
+	return results[0].Result, results[0].Err
+}
+
+// SetCustomAttributes sets a batch of custom attribute values. Every update is validated against
+// the customer's definitions before any is sent; if one is invalid, nothing is changed and an
+// error is returned. Otherwise each update is applied, with its value normalized as described for
+// CustomAttributeDefinition.Normalize, and its outcome reported, continuing past individual failures.
+func SetCustomAttributes(serviceURL string, billingID string, updates []CustomAttributeUpdate, maasToken string) ([]CustomAttributeResult, error) {
+	if len(updates) == 0 {
+		return nil, fmt.Errorf("no custom attribute updates given")
+	}
+	definitions, err := ListCustomAttributeDefinitions(serviceURL, billingID, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("error listing custom attribute definitions: %v", err)
+	}
+	byName := make(map[string]CustomAttributeDefinition, len(definitions))
+	for _, definition := range definitions {
+		byName[strings.ToLower(definition.Name)] = definition
+	}
+
+	values := make([]string, len(updates))
+	for i, update := range updates {
+		if update.DeviceID == "" || update.Name == "" {
+			return nil, fmt.Errorf("deviceID and name must not be empty")
+		}
+		definition, ok := byName[strings.ToLower(update.Name)]
+		if !ok {
+			return nil, fmt.Errorf("custom attribute %s is not defined", update.Name)
+		}
+		value, err := definition.Normalize(update.Value)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+
+	results := make([]CustomAttributeResult, 0, len(updates))
+	for i, update := range updates {
+		params := url.Values{}
+		params.Set("attributeName", byName[strings.ToLower(update.Name)].Name)
+		params.Set("attributeValue", values[i])
+		result, err := doDeviceAction(serviceURL, billingID, "setCustomAttributes", update.DeviceID, params, maasToken)
+		results = append(results, CustomAttributeResult{Update: update, Result: result, Err: err})
+	}
+	return results, nil
+}
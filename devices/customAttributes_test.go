@@ -0,0 +1,179 @@
+package devices
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestCustomAttributeDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition CustomAttributeDefinition
+		value      string
+		wantErr    bool
+	}{
+		{name: "text", definition: CustomAttributeDefinition{Name: "Site", Type: CustomAttributeText}, value: "anything"},
+		{name: "string alias", definition: CustomAttributeDefinition{Name: "Site", Type: "String"}, value: ""},
+		{name: "number", definition: CustomAttributeDefinition{Name: "Floor", Type: CustomAttributeNumber}, value: " 3.5 "},
+		{name: "integer alias", definition: CustomAttributeDefinition{Name: "Floor", Type: "Integer"}, value: "12"},
+		{name: "invalid number", definition: CustomAttributeDefinition{Name: "Floor", Type: CustomAttributeNumber}, value: "third", wantErr: true},
+		{name: "date", definition: CustomAttributeDefinition{Name: "Issued", Type: CustomAttributeDate}, value: "2024-01-02"},
+		{name: "invalid date", definition: CustomAttributeDefinition{Name: "Issued", Type: CustomAttributeDate}, value: "yesterday", wantErr: true},
+		{name: "boolean", definition: CustomAttributeDefinition{Name: "Loaner", Type: CustomAttributeBoolean}, value: "Yes"},
+		{name: "invalid boolean", definition: CustomAttributeDefinition{Name: "Loaner", Type: CustomAttributeBoolean}, value: "maybe", wantErr: true},
+		{name: "unsupported type", definition: CustomAttributeDefinition{Name: "Shape", Type: "Polygon"}, value: "square", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.definition.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// newCustomAttributeServer serves the custom attribute definitions and records each
+// setCustomAttributes request. Updates for device "offline" fail.
+func newCustomAttributeServer(t *testing.T, mu *sync.Mutex, sent *[]CustomAttributeUpdate) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/device-apis/devices/1.0/customAttributes/1234":
+			if r.Method != "GET" {
+				t.Errorf("Expected GET for definitions, got %s", r.Method)
+			}
+			w.Write([]byte(`{"customAttributes":{"customAttribute":[{"attributeName":"Site","attributeType":"Text"},{"attributeName":"Floor","attributeType":"Number"},{"attributeName":"Issued","attributeType":"Date"},{"attributeName":"Loaner","attributeType":"Boolean"}]}}`))
+		case "/device-apis/devices/1.0/setCustomAttributes/1234":
+			if r.Method != "POST" {
+				t.Errorf("Expected POST for update, got %s", r.Method)
+			}
+			if err := r.ParseForm(); err != nil {
+				t.Fatalf("Unexpected error parsing form: %v", err)
+			}
+			deviceID := r.URL.Query().Get("deviceId")
+			mu.Lock()
+			*sent = append(*sent, CustomAttributeUpdate{DeviceID: deviceID, Name: r.PostForm.Get("attributeName"), Value: r.PostForm.Get("attributeValue")})
+			mu.Unlock()
+			if deviceID == "offline" {
+				w.Write([]byte(`{"actionResponse":{"maas360DeviceID":"offline","actionStatus":1,"description":"Device not reachable"}}`))
+				return
+			}
+			fmt.Fprintf(w, `{"actionResponse":{"maas360DeviceID":%q,"actionStatus":0,"actionID":"42"}}`, deviceID)
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestSetCustomAttributes(t *testing.T) {
+	var mu sync.Mutex
+	var sent []CustomAttributeUpdate
+	server := newCustomAttributeServer(t, &mu, &sent)
+	defer server.Close()
+
+	updates := []CustomAttributeUpdate{
+		{DeviceID: "ApplABC", Name: "site", Value: "Berlin"},
+		{DeviceID: "offline", Name: "Floor", Value: "3"},
+		{DeviceID: "ApplDEF", Name: "Floor", Value: "4"},
+	}
+	results, err := SetCustomAttributes(server.URL, "1234", updates, "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(results) != len(updates) {
+		t.Fatalf("Expected %d results, got %d", len(updates), len(results))
+	}
+	if results[0].Err != nil || results[0].Result == nil || results[0].Result.ActionID != "42" {
+		t.Errorf("Expected first update to succeed, got %+v", results[0])
+	}
+	if results[1].Err == nil {
+		t.Error("Expected update for offline device to fail")
+	}
+	if results[2].Err != nil {
+		t.Errorf("Expected update after a failure to be applied, got %v", results[2].Err)
+	}
+
+	// Names are sent as defined, not as given.
+	expected := []CustomAttributeUpdate{
+		{DeviceID: "ApplABC", Name: "Site", Value: "Berlin"},
+		{DeviceID: "offline", Name: "Floor", Value: "3"},
+		{DeviceID: "ApplDEF", Name: "Floor", Value: "4"},
+	}
+	if len(sent) != len(expected) {
+		t.Fatalf("Expected %d requests, got %+v", len(expected), sent)
+	}
+	for i := range expected {
+		if sent[i] != expected[i] {
+			t.Errorf("Expected request %+v, got %+v", expected[i], sent[i])
+		}
+	}
+}
+
+func TestSetCustomAttributes_NormalizesValues(t *testing.T) {
+	var mu sync.Mutex
+	var sent []CustomAttributeUpdate
+	server := newCustomAttributeServer(t, &mu, &sent)
+	defer server.Close()
+
+	updates := []CustomAttributeUpdate{
+		{DeviceID: "ApplABC", Name: "Issued", Value: "Jan 2, 2006"},
+		{DeviceID: "ApplABC", Name: "Issued", Value: "02-Jan-2006"},
+		{DeviceID: "ApplABC", Name: "Loaner", Value: "on"},
+		{DeviceID: "ApplABC", Name: "Loaner", Value: "n"},
+		{DeviceID: "ApplABC", Name: "Floor", Value: " 3 "},
+		{DeviceID: "ApplABC", Name: "Site", Value: " Berlin "},
+	}
+	if _, err := SetCustomAttributes(server.URL, "1234", updates, "token"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"2006-01-02", "2006-01-02", "Yes", "No", "3", " Berlin "}
+	if len(sent) != len(expected) {
+		t.Fatalf("Expected %d requests, got %+v", len(expected), sent)
+	}
+	for i, want := range expected {
+		if sent[i].Value != want {
+			t.Errorf("Expected %s to be sent as %q, got %q", updates[i].Value, want, sent[i].Value)
+		}
+	}
+}
+
+func TestSetCustomAttributes_ValidationFailureSendsNothing(t *testing.T) {
+	tests := []struct {
+		name    string
+		updates []CustomAttributeUpdate
+	}{
+		{name: "invalid value", updates: []CustomAttributeUpdate{
+			{DeviceID: "ApplABC", Name: "Site", Value: "Berlin"},
+			{DeviceID: "ApplDEF", Name: "Floor", Value: "ground"},
+		}},
+		{name: "undefined attribute", updates: []CustomAttributeUpdate{
+			{DeviceID: "ApplABC", Name: "Building", Value: "B"},
+		}},
+		{name: "missing device ID", updates: []CustomAttributeUpdate{
+			{Name: "Site", Value: "Berlin"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var sent []CustomAttributeUpdate
+			server := newCustomAttributeServer(t, &mu, &sent)
+			defer server.Close()
+
+			if _, err := SetCustomAttributes(server.URL, "1234", tt.updates, "token"); err == nil {
+				t.Error("Expected validation error, got nil")
+			}
+			if len(sent) != 0 {
+				t.Errorf("Expected no updates to be sent, got %+v", sent)
+			}
+		})
+	}
+}
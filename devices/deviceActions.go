@@ -4,13 +4,16 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"maas360api/internal/constants"
 )
 
+// defaultRequesterWorkflow is recorded as the requester of actions that do not name one.
+const defaultRequesterWorkflow = "TEST"
+
 type DeviceAction struct {
 	ActionID    string `json:"actionId"`
 	ActionName  string `json:"actionName"`
@@ -42,13 +45,29 @@ func (d *DeviceActionsResponse) GetActionByName(actionName string) (*DeviceActio
 	return nil, fmt.Errorf("action with name %s not found", actionName)
 }
 
+// GetActionByID returns the action with the given ID, or an *UnsupportedActionError
+// listing the device's available actions if there is none.
 func (d *DeviceActionsResponse) GetActionByID(actionID string) (*DeviceAction, error) {
 	for _, action := range d.DeviceActions.Actions {
 		if action.ActionID == actionID {
 			return &action, nil
 		}
 	}
-	return nil, fmt.Errorf("action with ID %s not found", actionID)
+	available := make([]string, 0, len(d.DeviceActions.Actions))
+	for _, action := range d.DeviceActions.Actions {
+		available = append(available, action.ActionID)
+	}
+	return nil, &UnsupportedActionError{ActionID: actionID, Available: available}
+}
+
+// UnsupportedActionError is returned when an action is not available for a device.
+type UnsupportedActionError struct {
+	ActionID  string
+	Available []string // IDs of the actions the device supports
+}
+
+func (e *UnsupportedActionError) Error() string {
+	return fmt.Sprintf("action %s is not available for this device; available actions: %s", e.ActionID, strings.Join(e.Available, ", "))
 }
 
 // GetDeviceActions retrieves the list of available device actions for a specific device.
@@ -78,8 +97,6 @@ func GetDeviceActions(serviceURL string, billingID string, deviceID string, maas
 
 	var response DeviceActionsResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		fmt.Printf("Response body: %s\n", string(bodyBytes))
 		return nil, fmt.Errorf("error decoding response: %v", err)
 	}
 
@@ -92,26 +109,40 @@ func PerformDeviceAction(serviceURL string, billingID string, deviceID string, a
 	// MDM_LOCATE: Not applicable action for iOS devices
 	// MDM_SCHEDULE_OS_UPDATE: Available for iOS devices, requires additionalParams
 
+	return PerformDeviceActionWithOptions(serviceURL, billingID, deviceID, actionID, additionalParams, ActionOptions{}, maasToken)
+}
+
+// PerformDeviceActionWithOptions performs a specific action on a device. The options control
+// whether and how the action is checked against the device's available actions, and who is
+// recorded as the requester.
+func PerformDeviceActionWithOptions(serviceURL string, billingID string, deviceID string, actionID string, additionalParams map[string]string, opts ActionOptions, maasToken string) error {
 	if serviceURL == "" || billingID == "" || deviceID == "" || actionID == "" || maasToken == "" {
 		return fmt.Errorf("serviceURL, billingID, deviceID, actionID, and maasToken must not be empty")
 	}
 
-	actionsResponse, err := GetDeviceActions(serviceURL, billingID, deviceID, maasToken)
-	if err != nil {
-		return fmt.Errorf("error getting device actions: %v", err)
-	}
-
-	action, err := actionsResponse.GetActionByID(actionID)
-	if err != nil {
-		return fmt.Errorf("error getting action by name: %v", err)
+	actionName := actionID
+	if !opts.SkipCheck {
+		actionsResponse, err := getAvailableActions(serviceURL, billingID, deviceID, opts.Cache, maasToken)
+		if err != nil {
+			return fmt.Errorf("error getting device actions: %v", err)
+		}
+		action, err := actionsResponse.GetActionByID(actionID)
+		if err != nil {
+			return fmt.Errorf("error getting action by ID: %w", err)
+		}
+		actionName = action.ActionName
 	}
 
 	if (actionID == "ANDROID_CUSTOM_CMDS" || actionID == "MDM_SCHEDULE_OS_UPDATE") && additionalParams == nil {
 		return fmt.Errorf("additionalParams must not be nil for action %s", actionID)
 	}
 
-	fmt.Printf("Performing action: %s\n", action.ActionName)
-	err = doAction(serviceURL, billingID, deviceID, action.ActionID, action.ActionName, additionalParams, maasToken)
+	requesterWorkflow := opts.RequesterWorkflow
+	if requesterWorkflow == "" {
+		requesterWorkflow = defaultRequesterWorkflow
+	}
+
+	err := doAction(serviceURL, billingID, deviceID, actionID, actionName, additionalParams, requesterWorkflow, maasToken)
 
 	if err != nil {
 		return fmt.Errorf("error performing action: %v", err)
@@ -121,7 +152,7 @@ func PerformDeviceAction(serviceURL string, billingID string, deviceID string, a
 
 // doAction sends a request to perform a specific action on a device.
 // It constructs the request, sends it, and processes the response.
-func doAction(serviceURL string, billingID string, deviceID string, actionID string, actionName string, additionalParams map[string]string, maasToken string) error {
+func doAction(serviceURL string, billingID string, deviceID string, actionID string, actionName string, additionalParams map[string]string, requesterWorkflow string, maasToken string) error {
 	if serviceURL == "" || billingID == "" || deviceID == "" || actionID == "" || actionName == "" || maasToken == "" {
 		return fmt.Errorf("serviceURL, billingID, deviceID, actionName, and maasToken must not be empty")
 	}
@@ -129,15 +160,13 @@ func doAction(serviceURL string, billingID string, deviceID string, actionID str
 	var reqBodyRaw ActionRequest
 	reqBodyRaw.Name = actionName
 	reqBodyRaw.ExpiryDate = time.Now().Local().Unix() + 300 // 5 minutes from now
-	reqBodyRaw.RequesterWorflow = "TEST"
+	reqBodyRaw.RequesterWorflow = requesterWorkflow
 
 	if actionID == "ANDROID_CUSTOM_CMDS" && additionalParams == nil {
 		return fmt.Errorf("additionalParams must not be nil for action %s", actionID)
 	}
 
-	if actionID == "ANDROID_CUSTOM_CMDS" && additionalParams != nil {
-		reqBodyRaw.AdditionalParams = additionalParams
-	}
+	reqBodyRaw.AdditionalParams = additionalParams
 
 	reqBody, err := json.Marshal(reqBodyRaw)
 	if err != nil {
@@ -159,21 +188,7 @@ func doAction(serviceURL string, billingID string, deviceID string, actionID str
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		fmt.Println("Request URL:", url)
-		fmt.Println("Request Headers:")
-		for name, values := range req.Header {
-			for _, value := range values {
-				fmt.Printf("%s: %s\n", name, value)
-			}
-		}
-		fmt.Println("Request Body:", string(reqBody))
 		return fmt.Errorf("unexpected HTTP status: %s", resp.Status)
 	}
-
-	fmt.Println("Action performed successfully")
-
-	bodyBytes, _ := io.ReadAll(resp.Body)
-	fmt.Printf("Response body: %s\n", string(bodyBytes))
-
 	return nil
 }
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"io"
 	httputil "maas360api/internal/http"
+	"maas360api/types"
 	"net/http"
+	"time"
 )
 
 type CustomAttribute struct {
-	Name  string `json:"customAttributeName"`
-	Value any    `json:"customAttributeValue"`
+	Name  string               `json:"customAttributeName"`
+	Value types.FlexibleString `json:"customAttributeValue"`
 }
 
 type CustomAttributesWrapper struct {
@@ -25,16 +27,32 @@ type DeviceIdentity struct {
 	Vendor                 string                  `json:"vendor"`
 	PoNumber               string                  `json:"poNumber"`
 	PurchaseType           string                  `json:"purchaseType"`
-	PurchaseDate           string                  `json:"purchaseDate"`
+	PurchaseDate           types.Date              `json:"purchaseDate"`
 	PurchasePrice          string                  `json:"purchasePrice"`
 	WarrantyNumber         string                  `json:"warrantyNumber"`
-	WarrantyExpirationDate string                  `json:"warrantyExpirationDate"`
+	WarrantyExpirationDate types.Date              `json:"warrantyExpirationDate"`
 	WarrantyType           string                  `json:"warrantyType"`
 	Office                 string                  `json:"office,omitempty"`
 	Department             string                  `json:"department"`
 	CustomAttributes       CustomAttributesWrapper `json:"customAttributes"`
 }
 
+// PurchasedAt returns the purchase date, or the zero time if it is not set or not recognized.
+func (d DeviceIdentity) PurchasedAt() time.Time {
+	return d.PurchaseDate.Time()
+}
+
+// WarrantyExpiresAt returns the warranty expiration date, or the zero time if it is not set or not recognized.
+func (d DeviceIdentity) WarrantyExpiresAt() time.Time {
+	return d.WarrantyExpirationDate.Time()
+}
+
+// WarrantyExpired reports whether the warranty expired before now. It returns false if the expiration date is unknown.
+func (d DeviceIdentity) WarrantyExpired(now time.Time) bool {
+	expires := d.WarrantyExpiresAt()
+	return !expires.IsZero() && expires.Before(now)
+}
+
 type DeviceIdentityResponse struct {
 	DeviceIdentity DeviceIdentity `json:"deviceIdentity"`
 }
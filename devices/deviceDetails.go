@@ -5,40 +5,57 @@ import (
 	"fmt"
 	"io"
 	httputil "maas360api/internal/http"
-	"maas360api/internal/types"
+	"maas360api/types"
+	"time"
 )
 
 type DeviceIdentifiers struct {
-	Maas360DeviceID              string            `json:"maas360DeviceID"`
-	DeviceName                   string            `json:"deviceName"`
-	CustomAssetNumber            string            `json:"customAssetNumber"`
-	Ownership                    string            `json:"ownership"`
-	DeviceOwner                  string            `json:"deviceOwner"`
-	Username                     string            `json:"username"`
-	EmailAddress                 string            `json:"emailAddress"`
-	PlatformName                 string            `json:"platformName"`
-	SourceID                     int               `json:"sourceID"`
-	DeviceType                   string            `json:"deviceType"`
-	Manufacturer                 string            `json:"manufacturer"`
-	Model                        string            `json:"model"`
-	OSName                       string            `json:"osName"`
-	OSServicePack                string            `json:"osServicePack"`
-	IMEIESN                      any               `json:"imeiEsn"` // String or int64 | Empty string if not set
-	InstalledDate                string            `json:"installedDate"`
-	LastReported                 string            `json:"lastReported"`
-	InstalledDateInEpochms       types.FlexibleInt `json:"installedDateInEpochms"`
-	LastReportedInEpochms        types.FlexibleInt `json:"lastReportedInEpochms"`
-	DeviceStatus                 string            `json:"deviceStatus"`
-	Maas360ManagedStatus         string            `json:"maas360ManagedStatus"`
-	UDID                         string            `json:"udid"`
-	WifiMacAddress               string            `json:"wifiMacAddress"`
-	MailboxDeviceId              string            `json:"mailboxDeviceId"`
-	MailboxLastReported          string            `json:"mailboxLastReported"`
-	MailboxLastReportedInEpochms types.FlexibleInt `json:"mailboxLastReportedInEpochms"`
-	MailboxManaged               string            `json:"mailboxManaged"`
-	IsSupervisedDevice           bool              `json:"isSupervisedDevice"`
-	TestDevice                   bool              `json:"testDevice"`
-	UnifiedTravelerDeviceId      string            `json:"unifiedTravelerDeviceId"`
+	Maas360DeviceID              string               `json:"maas360DeviceID"`
+	DeviceName                   string               `json:"deviceName"`
+	CustomAssetNumber            string               `json:"customAssetNumber"`
+	Ownership                    string               `json:"ownership"`
+	DeviceOwner                  string               `json:"deviceOwner"`
+	Username                     string               `json:"username"`
+	EmailAddress                 string               `json:"emailAddress"`
+	PlatformName                 string               `json:"platformName"`
+	SourceID                     int                  `json:"sourceID"`
+	DeviceType                   string               `json:"deviceType"`
+	Manufacturer                 string               `json:"manufacturer"`
+	Model                        string               `json:"model"`
+	OSName                       string               `json:"osName"`
+	OSVersion                    types.OSVersion      `json:"osVersion"`
+	OSServicePack                string               `json:"osServicePack"`
+	IMEIESN                      types.FlexibleString `json:"imeiEsn"`
+	InstalledDate                string               `json:"installedDate"`
+	LastReported                 string               `json:"lastReported"`
+	InstalledDateInEpochms       types.EpochMillis    `json:"installedDateInEpochms"`
+	LastReportedInEpochms        types.EpochMillis    `json:"lastReportedInEpochms"`
+	DeviceStatus                 string               `json:"deviceStatus"`
+	Maas360ManagedStatus         string               `json:"maas360ManagedStatus"`
+	UDID                         string               `json:"udid"`
+	WifiMacAddress               string               `json:"wifiMacAddress"`
+	MailboxDeviceId              string               `json:"mailboxDeviceId"`
+	MailboxLastReported          string               `json:"mailboxLastReported"`
+	MailboxLastReportedInEpochms types.EpochMillis    `json:"mailboxLastReportedInEpochms"`
+	MailboxManaged               string               `json:"mailboxManaged"`
+	IsSupervisedDevice           types.FlexibleBool   `json:"isSupervisedDevice"`
+	TestDevice                   types.FlexibleBool   `json:"testDevice"`
+	UnifiedTravelerDeviceId      types.FlexibleString `json:"unifiedTravelerDeviceId"`
+}
+
+// LastReportedAt returns when the device last reported to MaaS360, or the zero time if unknown.
+func (d DeviceIdentifiers) LastReportedAt() time.Time {
+	return epochOrDate(d.LastReportedInEpochms, d.LastReported)
+}
+
+// InstalledAt returns when the MaaS360 agent was installed, or the zero time if unknown.
+func (d DeviceIdentifiers) InstalledAt() time.Time {
+	return epochOrDate(d.InstalledDateInEpochms, d.InstalledDate)
+}
+
+// MailboxLastReportedAt returns when the device's mailbox last reported, or the zero time if unknown.
+func (d DeviceIdentifiers) MailboxLastReportedAt() time.Time {
+	return epochOrDate(d.MailboxLastReportedInEpochms, d.MailboxLastReported)
 }
 
 type DeviceResponse struct {
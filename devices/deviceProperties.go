@@ -0,0 +1,71 @@
+package devices
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Ownership is the ownership type of a device.
+type Ownership string
+
+const (
+	CorporateOwned  Ownership = "Corporate Owned"
+	EmployeeOwned   Ownership = "Employee Owned"
+	CorporateShared Ownership = "Corporate Shared"
+)
+
+// Ownerships lists the ownership types accepted by MaaS360.
+var Ownerships = []Ownership{CorporateOwned, EmployeeOwned, CorporateShared}
+
+// ParseOwnership returns the ownership type matching s, compared case-insensitively.
+func ParseOwnership(s string) (Ownership, error) {
+	for _, ownership := range Ownerships {
+		if strings.EqualFold(strings.TrimSpace(s), string(ownership)) {
+			return ownership, nil
+		}
+	}
+	return "", fmt.Errorf("unknown ownership %q, expected one of %v", s, Ownerships)
+}
+
+// Valid reports whether o is one of the ownership types accepted by MaaS360.
+func (o Ownership) Valid() bool {
+	for _, ownership := range Ownerships {
+		if o == ownership {
+			return true
+		}
+	}
+	return false
+}
+
+// RenameDevice changes the name of a device.
+func RenameDevice(serviceURL string, billingID string, deviceID string, name string, maasToken string) (*ActionResult, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("device name must not be empty")
+	}
+	params := url.Values{}
+	params.Set("deviceName", name)
+	return doDeviceAction(serviceURL, billingID, "setDeviceName", deviceID, params, maasToken)
+}
+
+// SetDeviceOwnership changes the ownership type of a device.
+func SetDeviceOwnership(serviceURL string, billingID string, deviceID string, ownership Ownership, maasToken string) (*ActionResult, error) {
+	if !ownership.Valid() {
+		return nil, fmt.Errorf("unknown ownership %q, expected one of %v", ownership, Ownerships)
+	}
+	params := url.Values{}
+	params.Set("ownership", string(ownership))
+	return doDeviceAction(serviceURL, billingID, "setDeviceOwnership", deviceID, params, maasToken)
+}
+
+// SetTestDevice marks a device as a test device, or clears the mark.
+func SetTestDevice(serviceURL string, billingID string, deviceID string, testDevice bool, maasToken string) (*ActionResult, error) {
+	params := url.Values{}
+	if testDevice {
+		params.Set("testDevice", "Yes")
+	} else {
+		params.Set("testDevice", "No")
+	}
+	return doDeviceAction(serviceURL, billingID, "markAsTestDevice", deviceID, params, maasToken)
+}
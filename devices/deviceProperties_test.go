@@ -0,0 +1,36 @@
+package devices
+
+import "testing"
+
+func TestParseOwnership(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Ownership
+		wantErr bool
+	}{
+		{input: "Corporate Owned", want: CorporateOwned},
+		{input: "employee owned", want: EmployeeOwned},
+		{input: " CORPORATE SHARED ", want: CorporateShared},
+		{input: "Personal", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseOwnership(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseOwnership(%q): expected error %v, got %v", tt.input, tt.wantErr, err)
+		}
+		if got != tt.want {
+			t.Errorf("ParseOwnership(%q): expected %q, got %q", tt.input, tt.want, got)
+		}
+	}
+}
+
+func TestDevicePropertyValidation(t *testing.T) {
+	if _, err := SetDeviceOwnership("http://unused", "1234", "ApplABC", Ownership("Personal"), "token"); err == nil {
+		t.Error("Expected error for unknown ownership")
+	}
+	if _, err := RenameDevice("http://unused", "1234", "ApplABC", "  ", "token"); err == nil {
+		t.Error("Expected error for empty name")
+	}
+}
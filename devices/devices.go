@@ -2,16 +2,17 @@ package devices
 
 import (
 	httputil "maas360api/internal/http"
+	"maas360api/types"
 )
 
 type DeviceAttribute struct {
-	AttributeKey   string `json:"key"`
-	AttributeType  string `json:"type"`
-	AttributeValue any    `json:"value"`
+	AttributeKey   string               `json:"key"`
+	AttributeType  string               `json:"type"`
+	AttributeValue types.FlexibleString `json:"value"`
 }
 
 type DeviceAttributesWrapper struct {
-	DeviceAttributes []DeviceAttribute `json:"deviceAttribute"`
+	DeviceAttributes Attributes `json:"deviceAttribute"`
 }
 
 type DeviceAttributesResponse struct {
@@ -19,11 +20,4 @@ type DeviceAttributesResponse struct {
 	AttributeWrapper DeviceAttributesWrapper `json:"deviceAttributes"`
 }
 
-type DeviceActionResponse struct {
-	Maas360DeviceID string `json:"maas360DeviceId"`
-	ActionStatus    int    `json:"actionStatus"`
-	ActionID        int    `json:"actionID"`
-	Description     string `json:"description"`
-}
-
 var client = httputil.GetSharedClient()
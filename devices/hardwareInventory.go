@@ -17,6 +17,16 @@ type HardwareInventoryResponse struct {
 	DeviceHardware DeviceAttributesResponse `json:"deviceHardware"`
 }
 
+// Get returns the hardware attribute with the given key, for example Get("Total RAM").
+func (h *HardwareInventoryResponse) Get(key string) DeviceAttribute {
+	return h.DeviceHardware.AttributeWrapper.DeviceAttributes.Get(key)
+}
+
+// Summary decodes the well-known hardware attributes.
+func (h *HardwareInventoryResponse) Summary() HardwareSummary {
+	return h.DeviceHardware.AttributeWrapper.DeviceAttributes.HardwareSummary()
+}
+
 // GetHardwareInventory retrieves the hardware inventory for a specific device in MaaS360.
 // It requires a billing ID, device ID, and an authentication token.
 func GetHardwareInventory(serviceURL string, billingID string, deviceID string, maasToken string) (*HardwareInventoryResponse, error) {
@@ -82,27 +92,15 @@ func PrintHardwareInventory(serviceURL string, billingID string, deviceID string
 	}
 	fmt.Printf("Hardware Inventory for Device ID %s:\n", deviceID)
 	for _, attr := range hardwareInventory.DeviceHardware.AttributeWrapper.DeviceAttributes {
-		// Handle different types of attribute values
-		if attr.AttributeValue == nil {
+		if !attr.AttributeValue.IsSet {
 			fmt.Printf(" %s: <nil>\n", attr.AttributeKey)
 			continue
 		}
-		switch v := attr.AttributeValue.(type) {
-		case string:
-			// Attempt to parse the string as time
-			if t, err := time.Parse("2006-01-02T15:04:05", v); err == nil {
-				fmt.Printf(" %s: %s\n", attr.AttributeKey, t.UTC().Format(time.RFC1123))
-			} else {
-				fmt.Printf(" %s: %s\n", attr.AttributeKey, v)
-			}
-		case float64:
-			fmt.Printf(" %s: %.2f\n", attr.AttributeKey, v)
-		case int:
-			fmt.Printf(" %s: %d\n", attr.AttributeKey, v)
-		case bool:
-			fmt.Printf(" %s: %t\n", attr.AttributeKey, v)
-		default:
-			fmt.Printf(" %s: %v\n", attr.AttributeKey, v)
+		// Attempt to parse the value as time
+		if t, err := time.Parse("2006-01-02T15:04:05", attr.AttributeValue.String()); err == nil {
+			fmt.Printf(" %s: %s\n", attr.AttributeKey, t.UTC().Format(time.RFC1123))
+		} else {
+			fmt.Printf(" %s: %s\n", attr.AttributeKey, attr.AttributeValue)
 		}
 	}
 }
@@ -0,0 +1,160 @@
+package devices
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	httputil "maas360api/internal/http"
+	"maas360api/types"
+)
+
+// LocateActionID is the MDM action used to request a device's current location.
+const LocateActionID = "MDM_LOCATE"
+
+// ErrLocateNotApplicable is returned when MaaS360 does not offer the locate action for a device,
+// for example on platforms or enrollment modes where location services are unavailable.
+var ErrLocateNotApplicable = errors.New("locate is not applicable for this device")
+
+// Location is a single reported position of a device.
+type Location struct {
+	Latitude  float64   // Degrees
+	Longitude float64   // Degrees
+	Accuracy  float64   // Meters, 0 if not reported
+	Timestamp time.Time // When the device reported the position
+}
+
+// locationRecord is the wire format of a location entry.
+type locationRecord struct {
+	Latitude             types.FlexibleFloat  `json:"latitude"`
+	Longitude            types.FlexibleFloat  `json:"longitude"`
+	Accuracy             types.FlexibleString `json:"accuracy"`
+	LocatedTime          types.Date           `json:"locatedTime"`
+	LocatedTimeInEpochms types.EpochMillis    `json:"locatedTimeInEpochms"`
+}
+
+type locationOrLocations []locationRecord
+
+func (l *locationOrLocations) UnmarshalJSON(data []byte) error {
+	// Try as array
+	var arr []locationRecord
+	if err := json.Unmarshal(data, &arr); err == nil {
+		*l = arr
+		return nil
+	}
+	// Try as single object
+	var single locationRecord
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = []locationRecord{single}
+		return nil
+	}
+	return fmt.Errorf("locationOrLocations: cannot unmarshal %s", string(data))
+}
+
+type locateResponse struct {
+	DeviceLocation struct {
+		DeviceID          string `json:"maas360DeviceID"`
+		LocationAvailable string `json:"locationAvailable"`
+		locationRecord
+	} `json:"deviceLocation"`
+}
+
+type locationHistoryResponse struct {
+	LocationHistory struct {
+		DeviceID  string              `json:"maas360DeviceID"`
+		Locations locationOrLocations `json:"location"`
+	} `json:"locationHistory"`
+}
+
+// LocateDevice requests the current location of a device.
+// It returns ErrLocateNotApplicable if the device does not support the locate action.
+func LocateDevice(serviceURL string, billingID string, deviceID string, maasToken string) (*Location, error) {
+	if serviceURL == "" || billingID == "" || deviceID == "" || maasToken == "" {
+		return nil, fmt.Errorf("serviceURL, billingID, deviceID, and maasToken must not be empty")
+	}
+
+	actionsResponse, err := GetDeviceActions(serviceURL, billingID, deviceID, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("error getting device actions: %v", err)
+	}
+	if _, err := actionsResponse.GetActionByID(LocateActionID); err != nil {
+		return nil, fmt.Errorf("%w: device %s", ErrLocateNotApplicable, deviceID)
+	}
+
+	url := fmt.Sprintf("%s/device-apis/devices/1.0/locateDevice/%s?deviceId=%s", serviceURL, billingID, deviceID)
+	body, err := httputil.DoFormRequest("POST", url, nil, maasToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var response locateResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+	if response.DeviceLocation.LocationAvailable == "No" {
+		return nil, fmt.Errorf("no location available for device %s", deviceID)
+	}
+	location, err := response.DeviceLocation.locationRecord.toLocation()
+	if err != nil {
+		return nil, fmt.Errorf("error reading location of device %s: %v", deviceID, err)
+	}
+	return &location, nil
+}
+
+// GetLocationHistory retrieves the locations previously reported by a device.
+func GetLocationHistory(serviceURL string, billingID string, deviceID string, maasToken string) ([]Location, error) {
+	if serviceURL == "" || billingID == "" || deviceID == "" || maasToken == "" {
+		return nil, fmt.Errorf("serviceURL, billingID, deviceID, and maasToken must not be empty")
+	}
+
+	url := fmt.Sprintf("%s/device-apis/devices/1.0/locationHistory/%s?deviceId=%s", serviceURL, billingID, deviceID)
+	body, err := httputil.DoFormRequest("GET", url, nil, maasToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var response locationHistoryResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+
+	locations := make([]Location, 0, len(response.LocationHistory.Locations))
+	for i, record := range response.LocationHistory.Locations {
+		location, err := record.toLocation()
+		if err != nil {
+			return nil, fmt.Errorf("error reading location %d of device %s: %v", i, deviceID, err)
+		}
+		locations = append(locations, location)
+	}
+	return locations, nil
+}
+
+// toLocation converts a wire location record into a Location. Missing or malformed coordinates
+// are an error rather than 0,0, which is a real position.
+func (r locationRecord) toLocation() (Location, error) {
+	if !r.Latitude.IsSet {
+		return Location{}, fmt.Errorf("missing or invalid latitude")
+	}
+	if !r.Longitude.IsSet {
+		return Location{}, fmt.Errorf("missing or invalid longitude")
+	}
+	location := Location{
+		Latitude:  r.Latitude.Value,
+		Longitude: r.Longitude.Value,
+		Timestamp: r.LocatedTimeInEpochms.Time(),
+	}
+	if accuracy := strings.TrimSpace(r.Accuracy.String()); accuracy != "" {
+		value, err := strconv.ParseFloat(accuracy, 64)
+		if err != nil {
+			return Location{}, fmt.Errorf("invalid accuracy: %v", err)
+		}
+		location.Accuracy = value
+	}
+	if location.Timestamp.IsZero() {
+		location.Timestamp = r.LocatedTime.Time()
+	}
+	return location, nil
+}
@@ -0,0 +1,127 @@
+package devices
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newLocateServer serves the device actions listed in actions and the given locate and
+// location history bodies.
+func newLocateServer(t *testing.T, actions string, locateBody string, historyBody string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/device-apis/devices/1.0/deviceActions/"):
+			w.Write([]byte(`{"deviceActions":{"deviceAction":[` + actions + `]}}`))
+		case r.URL.Path == "/device-apis/devices/1.0/locateDevice/1234":
+			if r.Method != http.MethodPost {
+				t.Errorf("Expected POST, got %s", r.Method)
+			}
+			w.Write([]byte(locateBody))
+		case r.URL.Path == "/device-apis/devices/1.0/locationHistory/1234":
+			w.Write([]byte(historyBody))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+const locateAction = `{"actionId":"MDM_LOCATE","actionName":"Locate Device"}`
+
+func TestLocateDevice(t *testing.T) {
+	server := newLocateServer(t, locateAction,
+		`{"deviceLocation":{"maas360DeviceID":"AndrABC","locationAvailable":"Yes","latitude":"51.5","longitude":"-0.12","accuracy":"20","locatedTimeInEpochms":1700000000000}}`, "")
+	defer server.Close()
+
+	location, err := LocateDevice(server.URL, "1234", "AndrABC", "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if location.Latitude != 51.5 || location.Longitude != -0.12 || location.Accuracy != 20 {
+		t.Errorf("Unexpected location: %+v", location)
+	}
+	if location.Timestamp.UnixMilli() != 1700000000000 {
+		t.Errorf("Unexpected timestamp: %v", location.Timestamp)
+	}
+}
+
+func TestLocateDevice_NotApplicable(t *testing.T) {
+	server := newLocateServer(t, `{"actionId":"MDM_LOCK","actionName":"Lock Device"}`, "", "")
+	defer server.Close()
+
+	_, err := LocateDevice(server.URL, "1234", "ApplABC", "token")
+	if !errors.Is(err, ErrLocateNotApplicable) {
+		t.Errorf("Expected ErrLocateNotApplicable, got %v", err)
+	}
+}
+
+func TestLocateDevice_InvalidCoordinate(t *testing.T) {
+	server := newLocateServer(t, locateAction,
+		`{"deviceLocation":{"maas360DeviceID":"AndrABC","locationAvailable":"Yes","latitude":"unknown","longitude":"-0.12"}}`, "")
+	defer server.Close()
+
+	if _, err := LocateDevice(server.URL, "1234", "AndrABC", "token"); err == nil {
+		t.Error("Expected error for invalid latitude, got nil")
+	}
+}
+
+func TestGetLocationHistory(t *testing.T) {
+	server := newLocateServer(t, locateAction, "",
+		`{"locationHistory":{"maas360DeviceID":"AndrABC","location":[
+			{"latitude":51.5,"longitude":-0.12,"locatedTime":"2024-05-01T10:00:00"},
+			{"latitude":"48.85","longitude":"2.35"}
+		]}}`)
+	defer server.Close()
+
+	locations, err := GetLocationHistory(server.URL, "1234", "AndrABC", "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(locations) != 2 || locations[1].Latitude != 48.85 || locations[0].Timestamp.IsZero() {
+		t.Errorf("Unexpected locations: %+v", locations)
+	}
+}
+
+func TestGetLocationHistory_InvalidCoordinate(t *testing.T) {
+	server := newLocateServer(t, locateAction, "", `{"locationHistory":{"location":{"latitude":"51.5","longitude":""}}}`)
+	defer server.Close()
+
+	if _, err := GetLocationHistory(server.URL, "1234", "AndrABC", "token"); err == nil {
+		t.Error("Expected error for missing longitude, got nil")
+	}
+}
+
+func TestLocationRecord_ToLocation(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    Location
+		wantErr bool
+	}{
+		{name: "numbers", body: `{"latitude":40.7,"longitude":-74.0,"accuracy":12}`, want: Location{Latitude: 40.7, Longitude: -74.0, Accuracy: 12}},
+		{name: "strings", body: `{"latitude":"40.7","longitude":"-74.0"}`, want: Location{Latitude: 40.7, Longitude: -74.0}},
+		{name: "bad latitude", body: `{"latitude":"N40.7","longitude":"-74.0"}`, wantErr: true},
+		{name: "missing longitude", body: `{"latitude":"40.7"}`, wantErr: true},
+		{name: "bad accuracy", body: `{"latitude":40.7,"longitude":-74.0,"accuracy":"about 10m"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var record locationRecord
+			if err := json.Unmarshal([]byte(tt.body), &record); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			got, err := record.toLocation()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package devices
+
+// StopMailboxSync blocks a device in the messaging system, stopping mailbox synchronization.
+func StopMailboxSync(serviceURL string, billingID string, deviceID string, maasToken string) (*ActionResult, error) {
+	return doDeviceAction(serviceURL, billingID, "blockDeviceMessagingSystem", deviceID, nil, maasToken)
+}
+
+// StartMailboxSync approves a device in the messaging system, allowing mailbox synchronization.
+func StartMailboxSync(serviceURL string, billingID string, deviceID string, maasToken string) (*ActionResult, error) {
+	return doDeviceAction(serviceURL, billingID, "approveDeviceMessagingSystem", deviceID, nil, maasToken)
+}
@@ -13,7 +13,7 @@ type NetworkInformationWrapper struct {
 	NetworkInformation DeviceAttributesResponse `json:"networkInformation"`
 }
 
-func GetNetworkInfo(serviceURL string, billingID string, deviceID string, maasToken string) ([]DeviceAttribute, error) {
+func GetNetworkInfo(serviceURL string, billingID string, deviceID string, maasToken string) (Attributes, error) {
 	if serviceURL == "" || billingID == "" || deviceID == "" || maasToken == "" {
 		return nil, fmt.Errorf("billingID, deviceID, and maasToken must not be empty")
 	}
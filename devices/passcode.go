@@ -0,0 +1,12 @@
+package devices
+
+// ResetPasscode resets the passcode of a device. Where the platform supports it,
+// MaaS360 generates a temporary passcode, returned in ActionResult.TemporaryPasscode.
+func ResetPasscode(serviceURL string, billingID string, deviceID string, maasToken string) (*ActionResult, error) {
+	return doDeviceAction(serviceURL, billingID, "resetDevicePasscode", deviceID, nil, maasToken)
+}
+
+// ClearPasscode removes the passcode from a device so the user can set a new one.
+func ClearPasscode(serviceURL string, billingID string, deviceID string, maasToken string) (*ActionResult, error) {
+	return doDeviceAction(serviceURL, billingID, "clearPasscode", deviceID, nil, maasToken)
+}
@@ -0,0 +1,6 @@
+package devices
+
+// RefreshDeviceInformation asks a device to report its latest information to MaaS360.
+func RefreshDeviceInformation(serviceURL string, billingID string, deviceID string, maasToken string) (*ActionResult, error) {
+	return doDeviceAction(serviceURL, billingID, "refreshDeviceInformation", deviceID, nil, maasToken)
+}
@@ -7,68 +7,126 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
 
 	"maas360api/internal/constants"
-	"maas360api/internal/types"
+	"maas360api/internal/paging"
+	"maas360api/types"
 )
 
 // Device represents a MaaS360 device.
 type Device struct {
-	AppComplianceStatus          string            `json:"appComplianceState"`
-	AssetTag                     string            `json:"customAssetNumber"`
-	DeviceOwner                  string            `json:"deviceOwner"`
-	Email                        string            `json:"emailAddress"`
-	EncryptionStatus             string            `json:"encryptionStatus"`
-	FirstRegisteredInEpochms     types.FlexibleInt `json:"firstRegisteredInEpochms"`
-	ID                           types.FlexibleInt `json:"maas360DeviceID"`
-	IMEI                         types.FlexibleInt `json:"imeiEsn"`
-	InstalledDate                string            `json:"installedDate"`
-	InstalledDateInEpochms       types.FlexibleInt `json:"installedDateInEpochms"`
-	JailbreakStatus              string            `json:"jailbreakStatus"`
-	LastMDMRegisteredInEpochms   types.FlexibleInt `json:"lastMdmRegisteredInEpochms"`
-	LastReported                 string            `json:"lastReported"`
-	LastReportedInEpochms        types.FlexibleInt `json:"lastReportedInEpochms"`
-	LastRegisteredInEpochms      types.FlexibleInt `json:"lastRegisteredInEpochms"`
-	MacAddress                   string            `json:"wifiMacAddress"`
-	MailboxID                    types.FlexibleInt `json:"mailboxDeviceId"`
-	MailboxLastRepoted           string            `json:"mailboxLastReported"`
-	MailboxLastReportedInEpochms types.FlexibleInt `json:"mailboxLastReportedInEpochms"`
-	MailboxStatus                string            `json:"mailboxManaged"`
-	MDMPolicy                    string            `json:"mdmPolicy"`
-	MDMMailboxDeviceID           string            `json:"mdmMailboxDeviceId"`
-	ManagedStatus                string            `json:"maas360ManagedStatus"`
-	Manufacturer                 string            `json:"manufacturer"`
-	Model                        string            `json:"modelId"`
-	Name                         string            `json:"deviceName"`
-	OS                           string            `json:"osName"`
-	OSVersion                    any               `json:"osVersion"` // String, float or int32
-	Ownership                    string            `json:"ownership"`
-	PasscodeComplianceStatus     string            `json:"passcodeCompliance"`
-	PhoneNumber                  types.FlexibleInt `json:"phoneNumber"`
-	Platform                     string            `json:"platformName"`
-	PolicyComplianceStatus       string            `json:"policyComplianceState"`
-	RuleComplianceStatus         string            `json:"ruleComplianceState"`
-	SelectiveWipeStatus          string            `json:"selectiveWipeStatus"`
-	SerialNumber                 string            `json:"platformSerialNumber"`
-	ServicePack                  string            `json:"osServicePack"`
-	Status                       string            `json:"deviceStatus"`
-	Supervised                   bool              `json:"isSupervisedDevice"`
-	TestDevice                   bool              `json:"testDevice"`
-	TravelerDeviceID             types.FlexibleInt `json:"unifiedTravelerDeviceId"`
-	Type                         string            `json:"deviceType"`
-	UDID                         string            `json:"udid"`
-	UserDomain                   string            `json:"userDomain"`
-	Username                     string            `json:"username"`
-	EnrollmentMode               string            `json:"enrollmentMode"`
-	SourceID                     int32             `json:"sourceID"`
+	AppComplianceStatus          string               `json:"appComplianceState"`
+	AssetTag                     string               `json:"customAssetNumber"`
+	DeviceOwner                  string               `json:"deviceOwner"`
+	Email                        string               `json:"emailAddress"`
+	EncryptionStatus             string               `json:"encryptionStatus"`
+	FirstRegisteredInEpochms     types.EpochMillis    `json:"firstRegisteredInEpochms"`
+	ID                           types.FlexibleString `json:"maas360DeviceID"`
+	IMEI                         types.FlexibleString `json:"imeiEsn"`
+	InstalledDate                string               `json:"installedDate"`
+	InstalledDateInEpochms       types.EpochMillis    `json:"installedDateInEpochms"`
+	JailbreakStatus              string               `json:"jailbreakStatus"`
+	LastMDMRegisteredInEpochms   types.EpochMillis    `json:"lastMdmRegisteredInEpochms"`
+	LastReported                 string               `json:"lastReported"`
+	LastReportedInEpochms        types.EpochMillis    `json:"lastReportedInEpochms"`
+	LastRegisteredInEpochms      types.EpochMillis    `json:"lastRegisteredInEpochms"`
+	MacAddress                   string               `json:"wifiMacAddress"`
+	MailboxID                    types.FlexibleString `json:"mailboxDeviceId"`
+	MailboxLastRepoted           string               `json:"mailboxLastReported"`
+	MailboxLastReportedInEpochms types.EpochMillis    `json:"mailboxLastReportedInEpochms"`
+	MailboxStatus                string               `json:"mailboxManaged"`
+	MDMPolicy                    string               `json:"mdmPolicy"`
+	MDMMailboxDeviceID           string               `json:"mdmMailboxDeviceId"`
+	ManagedStatus                string               `json:"maas360ManagedStatus"`
+	Manufacturer                 string               `json:"manufacturer"`
+	Model                        string               `json:"modelId"`
+	Name                         string               `json:"deviceName"`
+	OS                           string               `json:"osName"`
+	OSVersion                    types.OSVersion      `json:"osVersion"`
+	Ownership                    string               `json:"ownership"`
+	PasscodeComplianceStatus     string               `json:"passcodeCompliance"`
+	PhoneNumber                  types.FlexibleString `json:"phoneNumber"`
+	Platform                     string               `json:"platformName"`
+	PolicyComplianceStatus       string               `json:"policyComplianceState"`
+	RuleComplianceStatus         string               `json:"ruleComplianceState"`
+	SelectiveWipeStatus          string               `json:"selectiveWipeStatus"`
+	SerialNumber                 string               `json:"platformSerialNumber"`
+	ServicePack                  string               `json:"osServicePack"`
+	Status                       string               `json:"deviceStatus"`
+	Supervised                   types.FlexibleBool   `json:"isSupervisedDevice"`
+	TestDevice                   types.FlexibleBool   `json:"testDevice"`
+	TravelerDeviceID             types.FlexibleString `json:"unifiedTravelerDeviceId"`
+	Type                         string               `json:"deviceType"`
+	UDID                         string               `json:"udid"`
+	UserDomain                   string               `json:"userDomain"`
+	Username                     string               `json:"username"`
+	EnrollmentMode               string               `json:"enrollmentMode"`
+	SourceID                     int32                `json:"sourceID"`
+}
+
+// LastReportedAt returns when the device last reported to MaaS360, or the zero time if unknown.
+func (d Device) LastReportedAt() time.Time {
+	return epochOrDate(d.LastReportedInEpochms, d.LastReported)
+}
+
+// InstalledAt returns when the MaaS360 agent was installed, or the zero time if unknown.
+func (d Device) InstalledAt() time.Time {
+	return epochOrDate(d.InstalledDateInEpochms, d.InstalledDate)
+}
+
+// MailboxLastReportedAt returns when the device's mailbox last reported, or the zero time if unknown.
+func (d Device) MailboxLastReportedAt() time.Time {
+	return epochOrDate(d.MailboxLastReportedInEpochms, d.MailboxLastRepoted)
+}
+
+// FirstRegisteredAt returns when the device first registered, or the zero time if unknown.
+func (d Device) FirstRegisteredAt() time.Time {
+	return d.FirstRegisteredInEpochms.Time()
+}
+
+// LastRegisteredAt returns when the device last registered, or the zero time if unknown.
+func (d Device) LastRegisteredAt() time.Time {
+	return d.LastRegisteredInEpochms.Time()
+}
+
+// LastMDMRegisteredAt returns when the device last registered for MDM, or the zero time if unknown.
+func (d Device) LastMDMRegisteredAt() time.Time {
+	return d.LastMDMRegisteredInEpochms.Time()
+}
+
+// IsStale reports whether the device has not reported within maxAge of now.
+// Devices that have never reported are stale.
+func (d Device) IsStale(maxAge time.Duration, now time.Time) bool {
+	lastReported := d.LastReportedAt()
+	return lastReported.IsZero() || now.Sub(lastReported) > maxAge
+}
+
+// epochOrDate returns the epoch timestamp if set, falling back to parsing the paired date string.
+func epochOrDate(epoch types.EpochMillis, date string) time.Time {
+	if t := epoch.Time(); !t.IsZero() {
+		return t
+	}
+	if date == "" {
+		return time.Time{}
+	}
+	t, _ := types.ParseDate(date)
+	return t
 }
 
 type DeviceOrDevices []Device
 
 type devices struct {
-	Device DeviceOrDevices `json:"device"`
+	Count      int             `json:"count"`
+	PageNumber int             `json:"pageNumber"`
+	PageSize   int             `json:"pageSize"`
+	Device     DeviceOrDevices `json:"device"`
 }
 
+// MaxSearchPageSize is the largest page size accepted by the device search API.
+const MaxSearchPageSize = 250
+
 type searchResponse struct {
 	Devices devices `json:"devices"`
 }
@@ -130,12 +188,44 @@ func SearchDevices(serviceURL string, billingID string, filters map[string]strin
 
 	searchURL := fmt.Sprintf("%s/device-apis/devices/2.0/search/customer/%s?", serviceURL, billingID) + searchFilters.Encode()
 
-	return doSearchDevicesRequest(searchURL, maasToken)
+	page, err := doSearchDevicesRequest(searchURL, maasToken)
+	if err != nil {
+		return nil, err
+	}
+	if len(page.Device) == 0 {
+		return nil, fmt.Errorf("no devices found")
+	}
+	return page.Device, nil
+}
+
+// SearchAllDevices pages through the device search API and returns every device matching the filters.
+// Any pageSize or pageNumber in filters is ignored. An empty result is not an error.
+func SearchAllDevices(serviceURL string, billingID string, filters map[string]string, maasToken string) ([]Device, error) {
+	if serviceURL == "" || billingID == "" || maasToken == "" {
+		return nil, fmt.Errorf("serviceURL, billing ID and maasToken cannot be empty")
+	}
+
+	searchFilters := url.Values{}
+	for key, value := range filters {
+		searchFilters.Set(key, value)
+	}
+	searchFilters.Set("pageSize", strconv.Itoa(MaxSearchPageSize))
+
+	return paging.All(MaxSearchPageSize, func(pageNumber int) ([]Device, int, error) {
+		searchFilters.Set("pageNumber", strconv.Itoa(pageNumber))
+		searchURL := fmt.Sprintf("%s/device-apis/devices/2.0/search/customer/%s?", serviceURL, billingID) + searchFilters.Encode()
+
+		page, err := doSearchDevicesRequest(searchURL, maasToken)
+		if err != nil {
+			return nil, 0, err
+		}
+		return page.Device, page.Count, nil
+	})
 }
 
-// doSearchRequest sends a search request to the MaaS360 API and returns the list of devices.
+// doSearchDevicesRequest sends a search request to the MaaS360 API and returns one page of devices.
 // It constructs the request, sends it, and processes the response.
-func doSearchDevicesRequest(url string, maasToken string) ([]Device, error) {
+func doSearchDevicesRequest(url string, maasToken string) (*devices, error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error creating HTTP request: %v", err)
@@ -166,10 +256,7 @@ func doSearchDevicesRequest(url string, maasToken string) ([]Device, error) {
 	if err := json.Unmarshal(body, &devicesResp); err != nil {
 		return nil, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
 	}
-	if len(devicesResp.Devices.Device) == 0 {
-		return nil, fmt.Errorf("no devices found")
-	}
-	return devicesResp.Devices.Device, nil
+	return &devicesResp.Devices, nil
 }
 
 // PrintDevices retrieves and prints the list of devices based on the provided filters.
@@ -1,69 +1,72 @@
 package devices
 
 import (
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"net/url"
-
-	"maas360api/internal/constants"
+	"strings"
+	"text/template"
 )
 
-type sendMessageResponse struct {
-	Maas360DeviceID string `json:"maas360DeviceID"`
-	ActionStatus    int8   `json:"actionStatus"`
-	Description     string `json:"description"`
-}
-
-type actionResponse struct {
-	ActionResponse sendMessageResponse `json:"actionResponse"`
-}
-
 // SendMessage sends a message to a specific device in MaaS360.
 // It requires a billing ID, device ID, an authentication token, and the message details.
-func SendMessage(serviceURL string, billingID string, deviceID string, messageTitle string, message string, maasToken string) error {
+// The title and message must not be empty. MaaS360 documents no length limits for them, so none
+// are enforced here; long messages are supported because they are sent as a form body rather than
+// in the URL. A message MaaS360 rejects is reported through the action status.
+func SendMessage(serviceURL string, billingID string, deviceID string, messageTitle string, message string, maasToken string) (*ActionResult, error) {
 	if serviceURL == "" || billingID == "" || deviceID == "" || maasToken == "" {
-		return fmt.Errorf("serviceURL, billingID, deviceID, and maasToken must not be empty")
+		return nil, fmt.Errorf("serviceURL, billingID, deviceID, and maasToken must not be empty")
+	}
+	if err := validateMessage(messageTitle, message); err != nil {
+		return nil, err
 	}
 
-	// Construct the message URL
-	messageURL := fmt.Sprintf("%s/device-apis/devices/1.0/sendMessage/%s?deviceId=%s&messageTitle=%s&message=%s", serviceURL, billingID, deviceID, url.PathEscape(messageTitle), url.PathEscape(message))
+	params := url.Values{}
+	params.Set("messageTitle", messageTitle)
+	params.Set("message", message)
 
-	return doSendMessageRequest(messageURL, maasToken)
+	result, err := doDeviceAction(serviceURL, billingID, "sendMessage", deviceID, params, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("message sending failed: %v", err)
+	}
+	return result, nil
 }
 
-// doSendMessageRequest sends a request to the MaaS360 API to send a message to a device.
-// It constructs the request, sends it, and processes the response.
-func doSendMessageRequest(url string, maasToken string) error {
-	req, err := http.NewRequest("POST", url, nil)
+// SendTemplatedMessage renders the title and message templates with the device's fields,
+// for example "Hello {{.Username}}, please update {{.Name}}", and sends the result to the device.
+func SendTemplatedMessage(serviceURL string, billingID string, device Device, titleTemplate string, messageTemplate string, maasToken string) (*ActionResult, error) {
+	if !device.ID.IsSet || device.ID.Value == "" {
+		return nil, fmt.Errorf("device has no MaaS360 device ID")
+	}
+	title, err := RenderMessage(titleTemplate, device)
 	if err != nil {
-		return fmt.Errorf("error creating HTTP request: %v", err)
+		return nil, fmt.Errorf("error rendering message title: %v", err)
 	}
-
-	req.Header.Set(constants.ContentTypeHeader, constants.ContentTypeForm)
-	req.Header.Set(constants.AcceptHeader, constants.ContentTypeJSON)
-	req.Header.Set(constants.AuthorizationHeader, fmt.Sprintf(constants.MaaSTokenPrefix, maasToken))
-
-	resp, err := client.Do(req)
+	message, err := RenderMessage(messageTemplate, device)
 	if err != nil {
-		return fmt.Errorf("error sending request: %v", err)
+		return nil, fmt.Errorf("error rendering message: %v", err)
 	}
-	defer resp.Body.Close()
+	return SendMessage(serviceURL, billingID, device.ID.Value, title, message, maasToken)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to send message, status code: %d", resp.StatusCode)
-	}
-	var response actionResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
-	}
-	if response.ActionResponse.Maas360DeviceID == "" {
-		return fmt.Errorf("no device ID returned in response")
+// RenderMessage executes a text/template against a device. Any exported Device field can be referenced;
+// referencing a field Device does not have is an error.
+func RenderMessage(messageTemplate string, device Device) (string, error) {
+	tmpl, err := template.New("message").Parse(messageTemplate)
+	if err != nil {
+		return "", err
 	}
-	if response.ActionResponse.ActionStatus != 0 {
-		return fmt.Errorf("message sending failed: %s", response.ActionResponse.Description)
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, device); err != nil {
+		return "", err
 	}
+	return sb.String(), nil
+}
 
-	fmt.Printf("Message sent successfully to device %s: %s\n", response.ActionResponse.Maas360DeviceID, response.ActionResponse.Description)
+// validateMessage checks a message against MaaS360's requirements before it is sent.
+// Only emptiness is checked; see SendMessage for why there is no length check.
+func validateMessage(messageTitle string, message string) error {
+	if messageTitle == "" || message == "" {
+		return fmt.Errorf("messageTitle and message must not be empty")
+	}
 	return nil
 }
@@ -0,0 +1,106 @@
+package devices
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"maas360api/types"
+)
+
+func TestSendMessage_FormEncoding(t *testing.T) {
+	title := "Q&A = today"
+	message := "1+1=2 & 50% off?"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Content-Type"); got != "application/x-www-form-urlencoded" {
+			t.Errorf("Expected form content type, got %q", got)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Unexpected error parsing form: %v", err)
+		}
+		if got := r.PostForm.Get("messageTitle"); got != title {
+			t.Errorf("Expected title %q, got %q", title, got)
+		}
+		if got := r.PostForm.Get("message"); got != message {
+			t.Errorf("Expected message %q, got %q", message, got)
+		}
+		if got := r.URL.Query().Get("deviceId"); got != "ApplABC" {
+			t.Errorf("Expected deviceId ApplABC, got %q", got)
+		}
+		w.Write([]byte(`{"actionResponse":{"maas360DeviceID":"ApplABC","actionStatus":0,"actionID":"7"}}`))
+	}))
+	defer server.Close()
+
+	if _, err := SendMessage(server.URL, "1234", "ApplABC", title, message, "token"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestValidateMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		title   string
+		message string
+		wantErr bool
+	}{
+		{name: "valid", title: "Title", message: "Body"},
+		{name: "empty title", title: "", message: "Body", wantErr: true},
+		{name: "empty message", title: "Title", message: "", wantErr: true},
+		{name: "long message", title: "Title", message: strings.Repeat("é", 5000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMessage(tt.title, tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRenderMessage(t *testing.T) {
+	device := Device{Username: "jdoe", Name: "Jane's iPhone"}
+
+	got, err := RenderMessage("Hi {{.Username}}, {{.Name}} needs an update", device)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := "Hi jdoe, Jane's iPhone needs an update"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	if _, err := RenderMessage("{{.NoSuchField}}", device); err == nil {
+		t.Error("Expected error for unknown field, got nil")
+	}
+}
+
+func TestSendTemplatedMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("deviceId"); got != "ApplABC" {
+			t.Errorf("Expected deviceId ApplABC, got %q", got)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Unexpected error parsing form: %v", err)
+		}
+		if got := r.PostForm.Get("message"); got != "Hi jdoe" {
+			t.Errorf("Expected rendered message, got %q", got)
+		}
+		w.Write([]byte(`{"actionResponse":{"maas360DeviceID":"ApplABC","actionStatus":0,"actionID":"7"}}`))
+	}))
+	defer server.Close()
+
+	device := Device{ID: types.FlexibleString{Value: "ApplABC", IsSet: true}, Username: "jdoe"}
+	if _, err := SendTemplatedMessage(server.URL, "1234", device, "Notice", "Hi {{.Username}}", "token"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := SendTemplatedMessage(server.URL, "1234", Device{Username: "jdoe"}, "Notice", "Hi", "token"); err == nil {
+		t.Error("Expected error for device without an ID, got nil")
+	}
+	if _, err := SendTemplatedMessage(server.URL, "1234", device, "Notice", "Hi {{.Nickname}}", "token"); err == nil {
+		t.Error("Expected error for a field Device does not have, got nil")
+	}
+}
@@ -10,15 +10,12 @@ import (
 	"maas360api/internal/constants"
 )
 
-type Attribute struct {
-	AttributeKey   string `json:"key"`
-	AttributeType  string `json:"type"`
-	AttributeValue any    `json:"value"` // changed to `any` to handle string, float, int
-}
+// Attribute is a key/value attribute of an installed software entry.
+type Attribute = DeviceAttribute
 
 type Software struct {
-	Name       string      `json:"swName"`
-	Attributes []Attribute `json:"swAttrs"`
+	Name       string     `json:"swName"`
+	Attributes Attributes `json:"swAttrs"`
 }
 
 type DeviceSoftwares struct {
@@ -96,21 +93,11 @@ func PrintSoftwareInstalled(serviceURL string, billingID string, deviceID string
 	for _, attr := range softwareInstalled.DeviceSoftwares.Softwares {
 		fmt.Printf("Software Name: %s\n", attr.Name)
 		for _, attribute := range attr.Attributes {
-			// Handle different types of attribute values
-			if attribute.AttributeValue == nil {
+			if !attribute.AttributeValue.IsSet {
 				fmt.Printf(" %s: <nil>\n", attribute.AttributeKey)
 				continue
 			}
-			switch v := attribute.AttributeValue.(type) {
-			case string:
-				fmt.Printf(" %s: %s\n", attribute.AttributeKey, v)
-			case float64:
-				fmt.Printf(" %s: %.2f\n", attribute.AttributeKey, v)
-			case int:
-				fmt.Printf(" %s: %d\n", attribute.AttributeKey, v)
-			default:
-				fmt.Printf(" %s: %v\n", attribute.AttributeKey, v)
-			}
+			fmt.Printf(" %s: %s\n", attribute.AttributeKey, attribute.AttributeValue)
 		}
 	}
 }
@@ -0,0 +1,154 @@
+package devices
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"maas360api/types"
+)
+
+// DeviceIdentityPatch holds new values for a device's asset and identity fields.
+// Nil fields are left unchanged.
+type DeviceIdentityPatch struct {
+	CustomAssetNumber      *string
+	Owner                  *string
+	Ownership              *string
+	Vendor                 *string
+	PoNumber               *string
+	PurchaseType           *string
+	PurchaseDate           *string
+	PurchasePrice          *string
+	WarrantyNumber         *string
+	WarrantyExpirationDate *string
+	WarrantyType           *string
+	Office                 *string
+	Department             *string
+}
+
+// FieldChange is a single field that differs between a device's identity and a patch.
+type FieldChange struct {
+	Field string // Field name as sent to MaaS360, for example "poNumber"
+	Old   string
+	New   string
+}
+
+// IdentityUpdate is the outcome of updating a device's identity.
+type IdentityUpdate struct {
+	DeviceID string
+	Changes  []FieldChange
+	Result   *ActionResult // Nil if there was nothing to change
+}
+
+// identityField pairs a patch field with its current value.
+type identityField struct {
+	name    string
+	patch   **string
+	current string
+}
+
+func (p *DeviceIdentityPatch) fields(current DeviceIdentity) []identityField {
+	return []identityField{
+		{"customAssetNumber", &p.CustomAssetNumber, current.CustomAssetNumber},
+		{"owner", &p.Owner, current.Owner},
+		{"ownership", &p.Ownership, current.Ownership},
+		{"vendor", &p.Vendor, current.Vendor},
+		{"poNumber", &p.PoNumber, current.PoNumber},
+		{"purchaseType", &p.PurchaseType, current.PurchaseType},
+		{"purchaseDate", &p.PurchaseDate, current.PurchaseDate.String()},
+		{"purchasePrice", &p.PurchasePrice, current.PurchasePrice},
+		{"warrantyNumber", &p.WarrantyNumber, current.WarrantyNumber},
+		{"warrantyExpirationDate", &p.WarrantyExpirationDate, current.WarrantyExpirationDate.String()},
+		{"warrantyType", &p.WarrantyType, current.WarrantyType},
+		{"office", &p.Office, current.Office},
+		{"department", &p.Department, current.Department},
+	}
+}
+
+// dateFields are the identity fields holding dates, which are compared as dates rather than text.
+var dateFields = map[string]bool{
+	"purchaseDate":           true,
+	"warrantyExpirationDate": true,
+}
+
+// unchanged reports whether value is the field's current value. Dates in different formats,
+// such as "2024-01-02" and "01/02/2024", and ownerships differing only in case are the same value.
+func (f identityField) unchanged(value string) bool {
+	if value == f.current {
+		return true
+	}
+	if f.name == "ownership" {
+		ownership, err := ParseOwnership(value)
+		return err == nil && strings.EqualFold(string(ownership), strings.TrimSpace(f.current))
+	}
+	if !dateFields[f.name] {
+		return false
+	}
+	newDate, err := types.ParseDate(value)
+	if err != nil {
+		return false
+	}
+	currentDate, err := types.ParseDate(f.current)
+	return err == nil && newDate.Equal(currentDate)
+}
+
+// normalize resolves the patch's Ownership to one of Ownerships, so that it is sent in the
+// form MaaS360 expects. An unknown ownership is reported as an error.
+func (p *DeviceIdentityPatch) normalize() error {
+	if p.Ownership == nil {
+		return nil
+	}
+	ownership, err := ParseOwnership(*p.Ownership)
+	if err != nil {
+		return err
+	}
+	value := string(ownership)
+	p.Ownership = &value
+	return nil
+}
+
+// Changes returns the fields of the patch whose values differ from the current identity.
+// A known ownership is reported in its canonical form, for example "Corporate Owned".
+func (p DeviceIdentityPatch) Changes(current DeviceIdentity) []FieldChange {
+	// An unknown ownership is left as given; UpdateDeviceIdentity rejects it.
+	p.normalize()
+	var changes []FieldChange
+	for _, field := range p.fields(current) {
+		if value := *field.patch; value != nil && !field.unchanged(*value) {
+			changes = append(changes, FieldChange{Field: field.name, Old: field.current, New: *value})
+		}
+	}
+	return changes
+}
+
+// UpdateDeviceIdentity reads the device's current identity and sends only the fields of the
+// patch that differ from it. If nothing differs, no update is sent.
+func UpdateDeviceIdentity(serviceURL string, billingID string, deviceID string, patch DeviceIdentityPatch, maasToken string) (*IdentityUpdate, error) {
+	if err := patch.normalize(); err != nil {
+		return nil, err
+	}
+	current, err := GetDeviceAttributes(serviceURL, billingID, deviceID, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("error getting device identity: %v", err)
+	}
+	return updateIdentityFields(serviceURL, billingID, deviceID, patch.Changes(*current), maasToken)
+}
+
+// updateIdentityFields sends the new values of the given changes.
+func updateIdentityFields(serviceURL string, billingID string, deviceID string, changes []FieldChange, maasToken string) (*IdentityUpdate, error) {
+	update := &IdentityUpdate{DeviceID: deviceID, Changes: changes}
+	if len(changes) == 0 {
+		return update, nil
+	}
+
+	params := url.Values{}
+	for _, change := range changes {
+		params.Set(change.Field, change.New)
+	}
+	result, err := doDeviceAction(serviceURL, billingID, "updateDeviceIdentity", deviceID, params, maasToken)
+	if err != nil {
+		return nil, err
+	}
+	update.Result = result
+	return update, nil
+}
@@ -0,0 +1,100 @@
+package devices
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDeviceIdentityPatch_Changes(t *testing.T) {
+	current := DeviceIdentity{Owner: "jdoe", PoNumber: "PO-1", Department: "Sales"}
+	owner, po, department := "jdoe", "PO-2", ""
+	patch := DeviceIdentityPatch{Owner: &owner, PoNumber: &po, Department: &department}
+
+	changes := patch.Changes(current)
+	expected := []FieldChange{
+		{Field: "poNumber", Old: "PO-1", New: "PO-2"},
+		{Field: "department", Old: "Sales", New: ""},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %+v", len(expected), changes)
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], changes[i])
+		}
+	}
+}
+
+func TestDeviceIdentityPatch_Changes_Dates(t *testing.T) {
+	var current DeviceIdentity
+	if err := json.Unmarshal([]byte(`{"purchaseDate":"2024-01-02","warrantyExpirationDate":"2027-01-02"}`), &current); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	purchased, warranty := "01/02/2024", "2028-01-02"
+	patch := DeviceIdentityPatch{PurchaseDate: &purchased, WarrantyExpirationDate: &warranty}
+
+	changes := patch.Changes(current)
+	if len(changes) != 1 || changes[0].Field != "warrantyExpirationDate" {
+		t.Errorf("Expected only the warranty date to change, got %+v", changes)
+	}
+}
+
+func TestDeviceIdentityPatch_Changes_Ownership(t *testing.T) {
+	current := DeviceIdentity{Ownership: "Corporate Owned"}
+
+	same := "corporate owned"
+	if changes := (DeviceIdentityPatch{Ownership: &same}).Changes(current); len(changes) != 0 {
+		t.Errorf("Expected no changes for ownership differing in case, got %+v", changes)
+	}
+
+	shared := "corporate shared"
+	changes := DeviceIdentityPatch{Ownership: &shared}.Changes(current)
+	if len(changes) != 1 || changes[0].New != string(CorporateShared) {
+		t.Errorf("Expected ownership change to %q, got %+v", CorporateShared, changes)
+	}
+	if shared != "corporate shared" {
+		t.Error("Expected Changes to leave the caller's patch unchanged")
+	}
+
+	personal := "Personal"
+	if _, err := UpdateDeviceIdentity("http://unused", "1234", "ApplABC", DeviceIdentityPatch{Ownership: &personal}, "token"); err == nil {
+		t.Error("Expected error for unknown ownership")
+	}
+}
+
+func TestParseAssetRecords(t *testing.T) {
+	csv := "SerialNumber,poNumber,Department\nC02ABC,PO-7,\nC02DEF,,Finance\n"
+	records, err := parseAssetRecords(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[0].serialNumber != "C02ABC" || records[0].patch.PoNumber == nil || *records[0].patch.PoNumber != "PO-7" {
+		t.Errorf("Unexpected first record: %+v", records[0])
+	}
+	if records[0].patch.Department != nil {
+		t.Error("Expected empty cell to leave department unchanged")
+	}
+	if records[1].patch.Department == nil || *records[1].patch.Department != "Finance" {
+		t.Errorf("Unexpected second record: %+v", records[1])
+	}
+
+	if _, err := parseAssetRecords(strings.NewReader("serialNumber,colour\nC02ABC,red\n")); err == nil {
+		t.Error("Expected error for unknown column")
+	}
+	if _, err := parseAssetRecords(strings.NewReader("poNumber\nPO-1\n")); err == nil {
+		t.Error("Expected error for missing serialNumber column")
+	}
+	if _, err := parseAssetRecords(strings.NewReader("serialNumber,colour\nC02ABC,\n")); err == nil {
+		t.Error("Expected error for unknown column with empty cells")
+	}
+	if _, err := parseAssetRecords(strings.NewReader("serialNumber,ownership\nC02ABC,Personal\n")); err == nil {
+		t.Error("Expected error for unknown ownership")
+	}
+	if _, err := parseAssetRecords(strings.NewReader("serialNumber,poNumber\nC02ABC,PO-1\nc02abc,PO-2\n")); err == nil {
+		t.Error("Expected error for duplicate serial number")
+	}
+}
@@ -7,6 +7,11 @@ import (
 
 // UpdateOS schedules an OS update for a specific device in MaaS360.
 func UpdateOS(serviceURL string, billingID string, deviceID string, osVersion string, targetLocalTime time.Time, maasToken string) error {
+	return UpdateOSWithOptions(serviceURL, billingID, deviceID, osVersion, targetLocalTime, ActionOptions{}, maasToken)
+}
+
+// UpdateOSWithOptions schedules an OS update for a specific device, using opts to check and record the action.
+func UpdateOSWithOptions(serviceURL string, billingID string, deviceID string, osVersion string, targetLocalTime time.Time, opts ActionOptions, maasToken string) error {
 	if serviceURL == "" || billingID == "" || deviceID == "" || osVersion == "" || targetLocalTime.Equal((time.Time{})) || maasToken == "" {
 		return fmt.Errorf("serviceURL, billingID, deviceID, osVersion, targetLocalTime, and maasToken must not be empty")
 	}
@@ -21,5 +26,5 @@ func UpdateOS(serviceURL string, billingID string, deviceID string, osVersion st
 		"detailsURL":         detailsURL,
 	}
 
-	return PerformDeviceAction(serviceURL, billingID, deviceID, "MDM_SCHEDULE_OS_UPDATE", additionalParams, maasToken)
+	return PerformDeviceActionWithOptions(serviceURL, billingID, deviceID, "MDM_SCHEDULE_OS_UPDATE", additionalParams, opts, maasToken)
 }
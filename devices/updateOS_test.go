@@ -0,0 +1,35 @@
+package devices
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestUpdateOS_SendsAdditionalParams(t *testing.T) {
+	var request ActionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/action-apis/actions/1.0/customer/1234/action/MDM_SCHEDULE_OS_UPDATE/device/ApplABC" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("Unexpected error decoding request body: %v", err)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	target := time.Date(2026, 11, 2, 22, 0, 0, 0, time.UTC)
+	if err := UpdateOSWithOptions(server.URL, "1234", "ApplABC", "17.4", target, ActionOptions{SkipCheck: true}, "token"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := request.AdditionalParams["targetLocalTime"]; got != "2026-11-02T22:00:00" {
+		t.Errorf("Expected targetLocalTime 2026-11-02T22:00:00, got %q", got)
+	}
+	if got := request.AdditionalParams["productVersion"]; got != "17.4" {
+		t.Errorf("Expected productVersion 17.4, got %q", got)
+	}
+}
@@ -0,0 +1,116 @@
+package devices
+
+import (
+	"fmt"
+)
+
+// Action IDs for the wipe family of MDM actions.
+const (
+	WipeActionID              = "MDM_WIPE"
+	SelectiveWipeActionID     = "MDM_SELECTIVE_WIPE"
+	CancelPendingWipeActionID = "MDM_CANCEL_PENDING_WIPE"
+)
+
+// WipeOptions guards a wipe request.
+type WipeOptions struct {
+	// Confirm must match the device's platform serial number, or its MaaS360
+	// device ID when the device does not report a serial number. It is not
+	// required to cancel a pending wipe.
+	Confirm string
+	// RequestedBy identifies who asked for the wipe. It is recorded as the
+	// action's requester workflow.
+	RequestedBy string
+	// DryRun validates the request without sending it to MaaS360.
+	DryRun bool
+}
+
+// WipeResult describes a wipe request that was issued, or would have been issued in dry-run mode.
+type WipeResult struct {
+	DeviceID     string
+	DeviceName   string
+	SerialNumber string
+	ActionID     string
+	RequestedBy  string
+	DryRun       bool
+}
+
+// WipeDevice issues a full wipe of a device, erasing all data on it.
+func WipeDevice(serviceURL string, billingID string, deviceID string, opts WipeOptions, maasToken string) (*WipeResult, error) {
+	return doWipeAction(serviceURL, billingID, deviceID, WipeActionID, true, opts, maasToken)
+}
+
+// SelectiveWipeDevice removes corporate data and configuration from a device, leaving personal data in place.
+func SelectiveWipeDevice(serviceURL string, billingID string, deviceID string, opts WipeOptions, maasToken string) (*WipeResult, error) {
+	return doWipeAction(serviceURL, billingID, deviceID, SelectiveWipeActionID, true, opts, maasToken)
+}
+
+// CancelPendingWipe cancels a wipe that has been issued but not yet executed on the device.
+// Cancelling does not need a confirmation token.
+func CancelPendingWipe(serviceURL string, billingID string, deviceID string, opts WipeOptions, maasToken string) (*WipeResult, error) {
+	return doWipeAction(serviceURL, billingID, deviceID, CancelPendingWipeActionID, false, opts, maasToken)
+}
+
+// doWipeAction checks the confirmation token against the device, if confirm is set, and the
+// device's available actions, then performs the wipe action unless a dry run was requested.
+func doWipeAction(serviceURL string, billingID string, deviceID string, actionID string, confirm bool, opts WipeOptions, maasToken string) (*WipeResult, error) {
+	if serviceURL == "" || billingID == "" || deviceID == "" || maasToken == "" {
+		return nil, fmt.Errorf("serviceURL, billingID, deviceID, and maasToken must not be empty")
+	}
+	if confirm && opts.Confirm == "" {
+		return nil, fmt.Errorf("a confirmation token is required for action %s", actionID)
+	}
+	if opts.RequestedBy == "" {
+		return nil, fmt.Errorf("the requester must be recorded for action %s", actionID)
+	}
+
+	device, err := findDevice(serviceURL, billingID, deviceID, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up device: %v", err)
+	}
+	expected := device.SerialNumber
+	if expected == "" {
+		expected = deviceID
+	}
+	if confirm && opts.Confirm != expected {
+		return nil, fmt.Errorf("confirmation token does not match device %s; refusing to perform %s", deviceID, actionID)
+	}
+
+	result := &WipeResult{
+		DeviceID:     deviceID,
+		DeviceName:   device.Name,
+		SerialNumber: device.SerialNumber,
+		ActionID:     actionID,
+		RequestedBy:  opts.RequestedBy,
+		DryRun:       opts.DryRun,
+	}
+
+	if opts.DryRun {
+		actionsResponse, err := GetDeviceActions(serviceURL, billingID, deviceID, maasToken)
+		if err != nil {
+			return nil, fmt.Errorf("error getting device actions: %v", err)
+		}
+		if _, err := actionsResponse.GetActionByID(actionID); err != nil {
+			return nil, fmt.Errorf("error getting action by ID: %w", err)
+		}
+		return result, nil
+	}
+
+	if err := PerformDeviceActionWithOptions(serviceURL, billingID, deviceID, actionID, nil, ActionOptions{RequesterWorkflow: opts.RequestedBy}, maasToken); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// findDevice looks up a single device by its MaaS360 device ID using the device search API.
+func findDevice(serviceURL string, billingID string, deviceID string, maasToken string) (*Device, error) {
+	found, err := SearchDevices(serviceURL, billingID, map[string]string{"maas360DeviceId": deviceID}, maasToken)
+	if err != nil {
+		return nil, err
+	}
+	for i := range found {
+		if found[i].ID.Value == deviceID {
+			return &found[i], nil
+		}
+	}
+	return nil, fmt.Errorf("device %s not found", deviceID)
+}
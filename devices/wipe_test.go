@@ -0,0 +1,83 @@
+package devices
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func newWipeServer(t *testing.T, actionCalls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/device-apis/devices/2.0/search/customer/1234":
+			w.Write([]byte(`{"devices":{"count":2,"device":[
+				{"maas360DeviceID":"ApplOTHER","platformSerialNumber":"C02OTHER"},
+				{"maas360DeviceID":"ApplABC","deviceName":"Jane's iPhone","platformSerialNumber":"C02ABC"}
+			]}}`))
+		case strings.HasPrefix(r.URL.Path, "/device-apis/devices/1.0/deviceActions/"):
+			w.Write([]byte(`{"deviceActions":{"deviceAction":[
+				{"actionId":"MDM_WIPE","actionName":"Wipe Device"},
+				{"actionId":"MDM_CANCEL_PENDING_WIPE","actionName":"Cancel Pending Wipe"}
+			]}}`))
+		case r.URL.Path == "/action-apis/actions/1.0/customer/1234/action/MDM_WIPE/device/ApplABC",
+			r.URL.Path == "/action-apis/actions/1.0/customer/1234/action/MDM_CANCEL_PENDING_WIPE/device/ApplABC":
+			atomic.AddInt32(actionCalls, 1)
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestWipeDevice(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            WipeOptions
+		wantErr         bool
+		wantActionCalls int32
+	}{
+		{name: "success", opts: WipeOptions{Confirm: "C02ABC", RequestedBy: "helpdesk"}, wantActionCalls: 1},
+		{name: "dry run", opts: WipeOptions{Confirm: "C02ABC", RequestedBy: "helpdesk", DryRun: true}},
+		{name: "confirm mismatch", opts: WipeOptions{Confirm: "C02OTHER", RequestedBy: "helpdesk"}, wantErr: true},
+		{name: "missing confirm", opts: WipeOptions{RequestedBy: "helpdesk"}, wantErr: true},
+		{name: "missing requester", opts: WipeOptions{Confirm: "C02ABC"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actionCalls int32
+			server := newWipeServer(t, &actionCalls)
+			defer server.Close()
+
+			result, err := WipeDevice(server.URL, "1234", "ApplABC", tt.opts, "token")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if actionCalls != tt.wantActionCalls {
+				t.Errorf("Expected %d action requests, got %d", tt.wantActionCalls, actionCalls)
+			}
+			if err != nil {
+				return
+			}
+			if result.DeviceName != "Jane's iPhone" || result.DryRun != tt.opts.DryRun {
+				t.Errorf("Unexpected result: %+v", result)
+			}
+		})
+	}
+}
+
+func TestCancelPendingWipe_NoConfirmation(t *testing.T) {
+	var actionCalls int32
+	server := newWipeServer(t, &actionCalls)
+	defer server.Close()
+
+	if _, err := CancelPendingWipe(server.URL, "1234", "ApplABC", WipeOptions{RequestedBy: "helpdesk"}, "token"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if actionCalls != 1 {
+		t.Errorf("Expected 1 action request, got %d", actionCalls)
+	}
+}
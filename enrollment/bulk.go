@@ -0,0 +1,101 @@
+package enrollment
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"maas360api/devices"
+	"maas360api/types"
+)
+
+// BulkResult is the outcome of one row of a bulk enrollment.
+type BulkResult struct {
+	Line       int // CSV line the request was read from
+	Request    Request
+	Enrollment *Enrollment
+	Err        error
+}
+
+// CreateFromCSV creates an enrollment request for every row of a CSV file. The CSV must have a
+// header row; the columns are userName, domain, email, platform, ownership, policy, phoneNumber
+// and sendEmail, of which the first four are required. Every row is validated before any request
+// is created; if one is invalid, nothing is created and an error is returned. Otherwise each row
+// is created and its outcome reported, continuing past individual failures.
+func CreateFromCSV(serviceURL string, billingID string, records io.Reader, maasToken string) ([]BulkResult, error) {
+	results, err := parseRequests(records)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		results[i].Enrollment, results[i].Err = Create(serviceURL, billingID, results[i].Request, maasToken)
+	}
+	return results, nil
+}
+
+// parseRequests reads and validates enrollment requests from CSV.
+func parseRequests(r io.Reader) ([]BulkResult, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading CSV header: %v", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"username", "domain", "email", "platform"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV has no %s column", required)
+		}
+	}
+
+	var results []BulkResult
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			if len(results) == 0 {
+				return nil, fmt.Errorf("CSV has no enrollment requests")
+			}
+			return results, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading CSV line %d: %v", line, err)
+		}
+		cell := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		request := Request{
+			UserName:    cell("username"),
+			Domain:      cell("domain"),
+			Email:       cell("email"),
+			Policy:      cell("policy"),
+			PhoneNumber: cell("phonenumber"),
+		}
+		if request.Platform, err = ParsePlatform(cell("platform")); err != nil {
+			return nil, fmt.Errorf("CSV line %d: %v", line, err)
+		}
+		if ownership := cell("ownership"); ownership != "" {
+			if request.Ownership, err = devices.ParseOwnership(ownership); err != nil {
+				return nil, fmt.Errorf("CSV line %d: %v", line, err)
+			}
+		}
+		if sendEmail := cell("sendemail"); sendEmail != "" {
+			value, ok := types.ParseFlexibleBool(sendEmail)
+			if !ok {
+				return nil, fmt.Errorf("CSV line %d: invalid sendEmail value %q", line, sendEmail)
+			}
+			request.SendEmail = value
+		}
+		if err := request.Validate(); err != nil {
+			return nil, fmt.Errorf("CSV line %d: %v", line, err)
+		}
+		results = append(results, BulkResult{Line: line, Request: request})
+	}
+}
@@ -0,0 +1,214 @@
+// Package enrollment creates and manages MaaS360 device enrollment requests.
+package enrollment
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"maas360api/devices"
+	httputil "maas360api/internal/http"
+	"maas360api/internal/paging"
+	"maas360api/internal/response"
+	"maas360api/types"
+)
+
+// Platform is the device platform an enrollment request is created for.
+type Platform string
+
+const (
+	IOS     Platform = "iOS"
+	Android Platform = "Android"
+	Windows Platform = "Windows"
+	Mac     Platform = "Mac"
+)
+
+// Platforms lists the platforms accepted by MaaS360 for enrollment.
+var Platforms = []Platform{IOS, Android, Windows, Mac}
+
+// ParsePlatform returns the platform matching s, compared case-insensitively.
+func ParsePlatform(s string) (Platform, error) {
+	for _, platform := range Platforms {
+		if strings.EqualFold(strings.TrimSpace(s), string(platform)) {
+			return platform, nil
+		}
+	}
+	return "", fmt.Errorf("unknown platform %q, expected one of %v", s, Platforms)
+}
+
+// MaxPageSize is the largest page size accepted by the enrollment search API.
+const MaxPageSize = 250
+
+// Request describes an enrollment request to create.
+type Request struct {
+	UserName    string            // Required
+	Domain      string            // Required
+	Email       string            // Required
+	Platform    Platform          // Required
+	Ownership   devices.Ownership // Optional, defaults to the customer's setting
+	Policy      string            // Optional MDM policy name, defaults to the platform's default policy
+	PhoneNumber string
+	SendEmail   bool // Email the enrollment URL and passcode to the user
+}
+
+// Validate checks that the request has every required field and valid enum values.
+func (r Request) Validate() error {
+	if r.UserName == "" || r.Domain == "" || r.Email == "" {
+		return fmt.Errorf("userName, domain, and email must not be empty")
+	}
+	if _, err := ParsePlatform(string(r.Platform)); err != nil {
+		return err
+	}
+	if r.Ownership != "" && !r.Ownership.Valid() {
+		return fmt.Errorf("unknown ownership %q, expected one of %v", r.Ownership, devices.Ownerships)
+	}
+	return nil
+}
+
+func (r Request) params() url.Values {
+	params := url.Values{}
+	params.Set("userName", r.UserName)
+	params.Set("domain", r.Domain)
+	params.Set("emailAddress", r.Email)
+	params.Set("platform", string(r.Platform))
+	if r.Ownership != "" {
+		params.Set("ownership", string(r.Ownership))
+	}
+	if r.Policy != "" {
+		params.Set("policyName", r.Policy)
+	}
+	if r.PhoneNumber != "" {
+		params.Set("phoneNumber", r.PhoneNumber)
+	}
+	if r.SendEmail {
+		params.Set("sendEmail", "Yes")
+	} else {
+		params.Set("sendEmail", "No")
+	}
+	return params
+}
+
+// Enrollment is an enrollment request known to MaaS360.
+type Enrollment struct {
+	RequestID   types.FlexibleString `json:"enrollmentRequestId"`
+	UserName    string               `json:"userName"`
+	Domain      string               `json:"domain"`
+	Email       string               `json:"emailAddress"`
+	Platform    string               `json:"platform"`
+	Ownership   string               `json:"ownership"`
+	Policy      string               `json:"policyName"`
+	Status      string               `json:"status"`
+	URL         string               `json:"enrollmentUrl"`
+	Passcode    types.FlexibleString `json:"passcode"`
+	CorporateID string               `json:"corporateIdentifier"`
+	CreatedDate types.Date           `json:"createdDate"`
+}
+
+type enrollmentOrEnrollments []Enrollment
+
+func (e *enrollmentOrEnrollments) UnmarshalJSON(data []byte) error {
+	// Try as array
+	var arr []Enrollment
+	if err := json.Unmarshal(data, &arr); err == nil {
+		*e = arr
+		return nil
+	}
+	// Try as single object
+	var single Enrollment
+	if err := json.Unmarshal(data, &single); err == nil {
+		*e = []Enrollment{single}
+		return nil
+	}
+	return fmt.Errorf("enrollmentOrEnrollments: cannot unmarshal %s", string(data))
+}
+
+// Create creates an enrollment request and returns its enrollment URL and passcode.
+func Create(serviceURL string, billingID string, request Request, maasToken string) (*Enrollment, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	body, err := doEnrollmentRequest("POST", serviceURL, billingID, "enrollDevice", request.params(), maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("error creating enrollment for %s: %v", request.UserName, err)
+	}
+
+	var wrapped struct {
+		Enrollment *Enrollment `json:"enrollment"`
+	}
+	if err := json.Unmarshal(body, &wrapped); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+	enrollment := wrapped.Enrollment
+	if enrollment == nil {
+		enrollment = &Enrollment{}
+		if err := json.Unmarshal(body, enrollment); err != nil {
+			return nil, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+		}
+	}
+	if enrollment.URL == "" {
+		return nil, fmt.Errorf("error creating enrollment for %s: no enrollment URL in response: %s", request.UserName, string(body))
+	}
+	return enrollment, nil
+}
+
+// ListPending retrieves every enrollment request that has not been completed yet.
+func ListPending(serviceURL string, billingID string, maasToken string) ([]Enrollment, error) {
+	params := url.Values{}
+	params.Set("status", "Pending")
+	params.Set("pageSize", strconv.Itoa(MaxPageSize))
+
+	return paging.All(MaxPageSize, func(pageNumber int) ([]Enrollment, int, error) {
+		params.Set("pageNumber", strconv.Itoa(pageNumber))
+		body, err := doEnrollmentRequest("GET", serviceURL, billingID, "searchEnrollments", params, maasToken)
+		if err != nil {
+			return nil, 0, err
+		}
+		var page struct {
+			Enrollments struct {
+				Count      int                     `json:"count"`
+				Enrollment enrollmentOrEnrollments `json:"enrollment"`
+			} `json:"enrollments"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, 0, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+		}
+		return page.Enrollments.Enrollment, page.Enrollments.Count, nil
+	})
+}
+
+// Resend sends the enrollment URL and passcode of a pending enrollment request to its user again.
+func Resend(serviceURL string, billingID string, requestID string, maasToken string) error {
+	return changeEnrollment(serviceURL, billingID, "resendEnrollment", requestID, maasToken)
+}
+
+// Cancel cancels a pending enrollment request.
+func Cancel(serviceURL string, billingID string, requestID string, maasToken string) error {
+	return changeEnrollment(serviceURL, billingID, "cancelEnrollment", requestID, maasToken)
+}
+
+func changeEnrollment(serviceURL string, billingID string, endpoint string, requestID string, maasToken string) error {
+	if requestID == "" {
+		return fmt.Errorf("requestID must not be empty")
+	}
+	params := url.Values{}
+	params.Set("enrollmentRequestId", requestID)
+	body, err := doEnrollmentRequest("POST", serviceURL, billingID, endpoint, params, maasToken)
+	if err != nil {
+		return fmt.Errorf("%s failed for request %s: %v", endpoint, requestID, err)
+	}
+	if _, err := response.Decode[response.Status](body, "response"); err != nil {
+		return fmt.Errorf("%s failed for request %s: %v", endpoint, requestID, err)
+	}
+	return nil
+}
+
+// doEnrollmentRequest sends a request to a device-apis enrollment endpoint and returns the response body.
+// Params are sent as a form body for POST requests and as the query string otherwise.
+func doEnrollmentRequest(method string, serviceURL string, billingID string, endpoint string, params url.Values, maasToken string) ([]byte, error) {
+	if serviceURL == "" || billingID == "" || maasToken == "" {
+		return nil, fmt.Errorf("serviceURL, billingID, and maasToken must not be empty")
+	}
+	return httputil.DoFormRequest(method, fmt.Sprintf("%s/device-apis/devices/1.0/%s/%s", serviceURL, endpoint, billingID), params, maasToken)
+}
@@ -0,0 +1,72 @@
+package enrollment
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"maas360api/devices"
+)
+
+func TestCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/device-apis/devices/1.0/enrollDevice/1234" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Unexpected error parsing form: %v", err)
+		}
+		if got := r.PostForm.Get("ownership"); got != string(devices.EmployeeOwned) {
+			t.Errorf("Expected ownership %s, got %q", devices.EmployeeOwned, got)
+		}
+		w.Write([]byte(`{"enrollment":{"enrollmentRequestId":77,"enrollmentUrl":"https://m.dm/abc","passcode":123456,"corporateIdentifier":"1234"}}`))
+	}))
+	defer server.Close()
+
+	request := Request{UserName: "jdoe", Domain: "corp", Email: "jdoe@example.com", Platform: IOS, Ownership: devices.EmployeeOwned}
+	enrollment, err := Create(server.URL, "1234", request, "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if enrollment.URL != "https://m.dm/abc" || enrollment.Passcode.String() != "123456" || enrollment.RequestID.String() != "77" {
+		t.Errorf("Unexpected enrollment: %+v", enrollment)
+	}
+
+	request.Platform = "BlackBerry"
+	if _, err := Create(server.URL, "1234", request, "token"); err == nil {
+		t.Error("Expected error for unknown platform")
+	}
+}
+
+func TestParseRequests(t *testing.T) {
+	csv := "userName,domain,email,platform,ownership,sendEmail\n" +
+		"jdoe,corp,jdoe@example.com,ios,corporate owned,yes\n" +
+		"asmith,corp,asmith@example.com,Android,,\n"
+	results, err := parseRequests(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(results))
+	}
+	first := results[0].Request
+	if first.Platform != IOS || first.Ownership != devices.CorporateOwned || !first.SendEmail || results[0].Line != 2 {
+		t.Errorf("Unexpected first request: %+v", results[0])
+	}
+	if results[1].Request.Platform != Android || results[1].Request.Ownership != "" {
+		t.Errorf("Unexpected second request: %+v", results[1])
+	}
+
+	invalid := []string{
+		"userName,domain,email\njdoe,corp,jdoe@example.com\n",
+		"userName,domain,email,platform\njdoe,corp,,iOS\n",
+		"userName,domain,email,platform,ownership\njdoe,corp,jdoe@example.com,iOS,Personal\n",
+		"userName,domain,email,platform\n",
+	}
+	for _, input := range invalid {
+		if _, err := parseRequests(strings.NewReader(input)); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
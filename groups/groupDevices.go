@@ -0,0 +1,93 @@
+package groups
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"maas360api/devices"
+	httputil "maas360api/internal/http"
+	"maas360api/internal/paging"
+)
+
+// MaxGroupPageSize is the largest page size accepted by the group device search API.
+const MaxGroupPageSize = 250
+
+type groupDevicesResponse struct {
+	Devices struct {
+		Count      int                     `json:"count"`
+		PageNumber int                     `json:"pageNumber"`
+		PageSize   int                     `json:"pageSize"`
+		Device     devices.DeviceOrDevices `json:"device"`
+	} `json:"devices"`
+}
+
+// ListGroupDevices returns one page of the devices in a device group. Pages are numbered from 1.
+// The total number of devices in the group is returned alongside the page.
+func ListGroupDevices(serviceURL string, billingID string, groupID string, pageNumber int, pageSize int, maasToken string) ([]devices.Device, int, error) {
+	if serviceURL == "" || billingID == "" || groupID == "" || maasToken == "" {
+		return nil, 0, fmt.Errorf("serviceURL, billingID, groupID, and maasToken must not be empty")
+	}
+	if pageNumber < 1 {
+		return nil, 0, fmt.Errorf("pageNumber must be at least 1, got %d", pageNumber)
+	}
+	if pageSize < 1 || pageSize > MaxGroupPageSize {
+		return nil, 0, fmt.Errorf("pageSize must be between 1 and %d, got %d", MaxGroupPageSize, pageSize)
+	}
+
+	params := url.Values{}
+	params.Set("deviceGroupId", groupID)
+	params.Set("pageNumber", strconv.Itoa(pageNumber))
+	params.Set("pageSize", strconv.Itoa(pageSize))
+	searchURL := fmt.Sprintf("%s/device-apis/devices/1.0/searchByDeviceGroup/%s", serviceURL, billingID)
+
+	body, err := httputil.DoFormRequest("GET", searchURL, params, maasToken)
+	if err != nil {
+		return nil, 0, err
+	}
+	var response groupDevicesResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, 0, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+	return response.Devices.Device, response.Devices.Count, nil
+}
+
+// ListAllGroupDevices pages through the devices in a device group and returns all of them.
+func ListAllGroupDevices(serviceURL string, billingID string, groupID string, maasToken string) ([]devices.Device, error) {
+	all, err := paging.All(MaxGroupPageSize, func(pageNumber int) ([]devices.Device, int, error) {
+		return ListGroupDevices(serviceURL, billingID, groupID, pageNumber, MaxGroupPageSize, maasToken)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing devices of group %s: %v", groupID, err)
+	}
+	return all, nil
+}
+
+// SearchGroupDevices returns the devices in a device group that also match the search filters.
+// The filters are those accepted by devices.SearchDevices.
+func SearchGroupDevices(serviceURL string, billingID string, groupID string, filters map[string]string, maasToken string) ([]devices.Device, error) {
+	members, err := ListAllGroupDevices(serviceURL, billingID, groupID, maasToken)
+	if err != nil {
+		return nil, err
+	}
+	if len(filters) == 0 {
+		return members, nil
+	}
+
+	inGroup := make(map[string]bool, len(members))
+	for _, device := range members {
+		inGroup[device.ID.String()] = true
+	}
+	found, err := devices.SearchAllDevices(serviceURL, billingID, filters, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("error searching devices: %v", err)
+	}
+	var matched []devices.Device
+	for _, device := range found {
+		if inGroup[device.ID.String()] {
+			matched = append(matched, device)
+		}
+	}
+	return matched, nil
+}
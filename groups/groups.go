@@ -0,0 +1,155 @@
+// Package groups provides access to MaaS360 device groups and user groups.
+package groups
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	httputil "maas360api/internal/http"
+	"maas360api/types"
+)
+
+// GroupType is the kind of a MaaS360 group.
+type GroupType int
+
+const (
+	UnknownGroupType   GroupType = -1 // Group type missing or not recognized
+	DeviceGroup        GroupType = 0  // Device group defined by a saved search
+	UserGroup          GroupType = 1  // Local user group
+	DirectoryUserGroup GroupType = 2  // User group imported from a directory
+)
+
+// IsUserGroup reports whether t is a local or directory user group.
+func (t GroupType) IsUserGroup() bool {
+	return t == UserGroup || t == DirectoryUserGroup
+}
+
+func (t GroupType) String() string {
+	switch t {
+	case DeviceGroup:
+		return "Device Group"
+	case UserGroup:
+		return "User Group"
+	case DirectoryUserGroup:
+		return "Directory User Group"
+	case UnknownGroupType:
+		return "Unknown"
+	default:
+		return fmt.Sprintf("GroupType(%d)", int(t))
+	}
+}
+
+// UnmarshalJSON decodes a numeric group type. A null value, or one that is not numeric or not
+// one of the known types, is read as UnknownGroupType, so it is never mistaken for a device group.
+func (t *GroupType) UnmarshalJSON(data []byte) error {
+	*t = UnknownGroupType
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+	var value types.FlexibleInt
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if !value.IsSet {
+		return nil
+	}
+	switch GroupType(value.Value) {
+	case DeviceGroup, UserGroup, DirectoryUserGroup:
+		*t = GroupType(value.Value)
+	}
+	return nil
+}
+
+// Group represents a MaaS360 device group or user group.
+type Group struct {
+	ID             types.FlexibleString `json:"groupID"`
+	Name           string               `json:"groupName"`
+	Type           GroupType            `json:"groupType"`
+	Description    string               `json:"description"`
+	CreatedBy      string               `json:"createdBy"`
+	CreatedOn      types.Date           `json:"createdOn"`
+	LastModifiedOn types.Date           `json:"lastModifiedOn"`
+}
+
+// UnmarshalJSON decodes a group. A group without a groupType has type UnknownGroupType.
+func (g *Group) UnmarshalJSON(data []byte) error {
+	type group Group
+	decoded := group{Type: UnknownGroupType}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*g = Group(decoded)
+	return nil
+}
+
+type groupOrGroups []Group
+
+func (g *groupOrGroups) UnmarshalJSON(data []byte) error {
+	// Try as array
+	var arr []Group
+	if err := json.Unmarshal(data, &arr); err == nil {
+		*g = arr
+		return nil
+	}
+	// Try as single object
+	var single Group
+	if err := json.Unmarshal(data, &single); err == nil {
+		*g = []Group{single}
+		return nil
+	}
+	return fmt.Errorf("groupOrGroups: cannot unmarshal %s", string(data))
+}
+
+type groupsResponse struct {
+	Groups struct {
+		Group groupOrGroups `json:"group"`
+	} `json:"groups"`
+}
+
+// ListGroups retrieves every device group and user group visible to the administrator.
+func ListGroups(serviceURL string, billingID string, maasToken string) ([]Group, error) {
+	body, err := doGroupRequest("GET", serviceURL, billingID, "groups", nil, maasToken)
+	if err != nil {
+		return nil, err
+	}
+	var response groupsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+	return response.Groups.Group, nil
+}
+
+// ListDeviceGroups retrieves the device groups.
+func ListDeviceGroups(serviceURL string, billingID string, maasToken string) ([]Group, error) {
+	return listGroupsOfType(serviceURL, billingID, maasToken, func(t GroupType) bool { return t == DeviceGroup })
+}
+
+// ListUserGroups retrieves the local and directory user groups.
+func ListUserGroups(serviceURL string, billingID string, maasToken string) ([]Group, error) {
+	return listGroupsOfType(serviceURL, billingID, maasToken, GroupType.IsUserGroup)
+}
+
+func listGroupsOfType(serviceURL string, billingID string, maasToken string, match func(GroupType) bool) ([]Group, error) {
+	all, err := ListGroups(serviceURL, billingID, maasToken)
+	if err != nil {
+		return nil, err
+	}
+	var groups []Group
+	for _, group := range all {
+		if match(group.Type) {
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+// doGroupRequest sends a request to a group-apis endpoint and returns the response body.
+// Params are sent as a form body for POST requests and as the query string otherwise.
+func doGroupRequest(method string, serviceURL string, billingID string, endpoint string, params url.Values, maasToken string) ([]byte, error) {
+	if serviceURL == "" || billingID == "" || maasToken == "" {
+		return nil, fmt.Errorf("serviceURL, billingID, and maasToken must not be empty")
+	}
+	return httputil.DoFormRequest(method, fmt.Sprintf("%s/group-apis/group/1.0/%s/customer/%s", serviceURL, endpoint, billingID), params, maasToken)
+}
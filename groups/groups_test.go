@@ -0,0 +1,115 @@
+package groups
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListUserGroups(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/group-apis/group/1.0/groups/customer/1234" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"groups":{"group":[
+			{"groupID":101,"groupName":"All iPads","groupType":0},
+			{"groupID":"202","groupName":"Sales","groupType":"1"},
+			{"groupID":303,"groupName":"AD Engineering","groupType":2},
+			{"groupID":404,"groupName":"Unnamed type","groupType":"dynamic"},
+			{"groupID":505,"groupName":"Future type","groupType":7},
+			{"groupID":606,"groupName":"No type"},
+			{"groupID":707,"groupName":"Null type","groupType":null}
+		]}}`))
+	}))
+	defer server.Close()
+
+	userGroups, err := ListUserGroups(server.URL, "1234", "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(userGroups) != 2 || userGroups[0].ID.String() != "202" || userGroups[1].Type != DirectoryUserGroup {
+		t.Errorf("Unexpected user groups: %+v", userGroups)
+	}
+
+	deviceGroups, err := ListDeviceGroups(server.URL, "1234", "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(deviceGroups) != 1 || deviceGroups[0].Name != "All iPads" {
+		t.Errorf("Unexpected device groups: %+v", deviceGroups)
+	}
+
+	all, err := ListGroups(server.URL, "1234", "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(all) != 7 {
+		t.Fatalf("Expected 7 groups, got %d", len(all))
+	}
+	for _, group := range all[3:] {
+		if group.Type != UnknownGroupType {
+			t.Errorf("Expected %s to have an unknown type, got %s", group.Name, group.Type)
+		}
+	}
+}
+
+func TestSearchGroupDevices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/device-apis/devices/1.0/searchByDeviceGroup/1234":
+			if got := r.URL.Query().Get("deviceGroupId"); got != "101" {
+				t.Errorf("Expected deviceGroupId 101, got %q", got)
+			}
+			w.Write([]byte(`{"devices":{"count":2,"device":[{"maas360DeviceID":"ApplA"},{"maas360DeviceID":"ApplB"}]}}`))
+		case "/device-apis/devices/2.0/search/customer/1234":
+			w.Write([]byte(`{"devices":{"count":2,"device":[{"maas360DeviceID":"ApplB"},{"maas360DeviceID":"ApplC"}]}}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	members, err := SearchGroupDevices(server.URL, "1234", "101", nil, "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(members) != 2 {
+		t.Errorf("Expected 2 group devices, got %d", len(members))
+	}
+
+	matched, err := SearchGroupDevices(server.URL, "1234", "101", map[string]string{"platformName": "iOS"}, "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(matched) != 1 || matched[0].ID.String() != "ApplB" {
+		t.Errorf("Expected only ApplB, got %+v", matched)
+	}
+}
+
+func TestAddUsersToGroup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/group-apis/group/1.0/addUsersToGroup/customer/1234" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Unexpected error parsing form: %v", err)
+		}
+		if got := r.PostForm.Get("userIdentifiers"); got != "U1,U2" {
+			t.Errorf("Expected userIdentifiers U1,U2, got %q", got)
+		}
+		w.Write([]byte(`{"response":{"status":"Success"}}`))
+	}))
+	defer server.Close()
+
+	result, err := AddUsersToGroup(server.URL, "1234", "202", []string{"U1", "U2"}, "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.GroupID != "202" {
+		t.Errorf("Expected group 202, got %s", result.GroupID)
+	}
+
+	if _, err := RemoveUsersFromGroup(server.URL, "1234", "202", nil, "token"); err == nil {
+		t.Error("Expected error for empty user list")
+	}
+}
@@ -0,0 +1,53 @@
+package groups
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"maas360api/internal/response"
+)
+
+// MembershipResult is the outcome of changing the members of a user group.
+type MembershipResult struct {
+	GroupID     string
+	Status      string // Status returned by MaaS360
+	Description string // Description returned by MaaS360
+}
+
+// AddUsersToGroup adds users, identified by their MaaS360 user identifiers, to a user group.
+func AddUsersToGroup(serviceURL string, billingID string, groupID string, userIdentifiers []string, maasToken string) (*MembershipResult, error) {
+	return changeMembers(serviceURL, billingID, "addUsersToGroup", groupID, userIdentifiers, maasToken)
+}
+
+// RemoveUsersFromGroup removes users, identified by their MaaS360 user identifiers, from a user group.
+func RemoveUsersFromGroup(serviceURL string, billingID string, groupID string, userIdentifiers []string, maasToken string) (*MembershipResult, error) {
+	return changeMembers(serviceURL, billingID, "removeUsersFromGroup", groupID, userIdentifiers, maasToken)
+}
+
+func changeMembers(serviceURL string, billingID string, endpoint string, groupID string, userIdentifiers []string, maasToken string) (*MembershipResult, error) {
+	if groupID == "" {
+		return nil, fmt.Errorf("groupID must not be empty")
+	}
+	if len(userIdentifiers) == 0 {
+		return nil, fmt.Errorf("no users given")
+	}
+	for _, userIdentifier := range userIdentifiers {
+		if userIdentifier == "" || strings.Contains(userIdentifier, ",") {
+			return nil, fmt.Errorf("invalid user identifier %q", userIdentifier)
+		}
+	}
+
+	params := url.Values{}
+	params.Set("groupId", groupID)
+	params.Set("userIdentifiers", strings.Join(userIdentifiers, ","))
+	body, err := doGroupRequest("POST", serviceURL, billingID, endpoint, params, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed for group %s: %v", endpoint, groupID, err)
+	}
+	result, err := response.Decode[response.Status](body, "response")
+	if err != nil {
+		return nil, fmt.Errorf("%s failed for group %s: %v", endpoint, groupID, err)
+	}
+	return &MembershipResult{GroupID: groupID, Status: result.Status, Description: result.Description}, nil
+}
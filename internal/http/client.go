@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"maas360api/internal/constants"
@@ -25,6 +27,24 @@ func NewClient() *http.Client {
 	}
 }
 
+// UploadResponseTimeout bounds how long an upload client waits for the response once the
+// request body has been sent.
+const UploadResponseTimeout = 5 * time.Minute
+
+// NewUploadClient creates an HTTP client for streaming large request bodies. It has no overall
+// timeout, since an upload may take longer than DefaultTimeout, but still bounds the wait for the
+// response after the body is sent.
+func NewUploadClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			MaxIdleConns:          100,
+			MaxIdleConnsPerHost:   10,
+			IdleConnTimeout:       90 * time.Second,
+			ResponseHeaderTimeout: UploadResponseTimeout,
+		},
+	}
+}
+
 // GetSharedClient returns a shared HTTP client instance
 var sharedClient *http.Client
 
@@ -37,12 +57,14 @@ func GetSharedClient() *http.Client {
 
 // RequestOptions contains options for making HTTP requests
 type RequestOptions struct {
-	Method      string
-	URL         string
-	Body        io.Reader
-	ContentType string
-	MaaSToken   string
-	Context     context.Context
+	Method        string
+	URL           string
+	Body          io.Reader
+	ContentType   string
+	ContentLength int64 // Length of Body in bytes, if known; 0 lets net/http determine it
+	MaaSToken     string
+	Context       context.Context
+	Client        *http.Client // Defaults to the shared client
 }
 
 // DoMaaSRequest performs a standard MaaS360 API request with proper headers
@@ -56,6 +78,9 @@ func DoMaaSRequest(opts RequestOptions) (*http.Response, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error creating HTTP request: %v", err)
 	}
+	if opts.ContentLength > 0 {
+		req.ContentLength = opts.ContentLength
+	}
 
 	// Set common headers
 	req.Header.Set(constants.AcceptHeader, constants.ContentTypeJSON)
@@ -68,7 +93,11 @@ func DoMaaSRequest(opts RequestOptions) (*http.Response, error) {
 		req.Header.Set(constants.ContentTypeHeader, constants.ContentTypeJSON)
 	}
 
-	resp, err := GetSharedClient().Do(req)
+	client := opts.Client
+	if client == nil {
+		client = GetSharedClient()
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error making HTTP request: %v", err)
 	}
@@ -80,3 +109,27 @@ func DoMaaSRequest(opts RequestOptions) (*http.Response, error) {
 
 	return resp, nil
 }
+
+// DoFormRequest performs a MaaS360 API request and returns the response body.
+// Params are sent as a form body for POST requests and as the query string otherwise.
+func DoFormRequest(method string, requestURL string, params url.Values, maasToken string) ([]byte, error) {
+	opts := RequestOptions{Method: method, URL: requestURL, MaaSToken: maasToken}
+	if method == "POST" {
+		opts.Body = strings.NewReader(params.Encode())
+		opts.ContentType = constants.ContentTypeForm
+	} else if len(params) > 0 {
+		opts.URL += "?" + params.Encode()
+	}
+
+	resp, err := DoMaaSRequest(opts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %v", err)
+	}
+	return body, nil
+}
@@ -2,6 +2,8 @@ package http
 
 import (
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 )
@@ -53,3 +55,54 @@ func TestGetSharedClient(t *testing.T) {
 		t.Fatal("Expected shared client to be created, got nil")
 	}
 }
+
+// TestNewUploadClient verifies that the upload client has no overall timeout
+func TestNewUploadClient(t *testing.T) {
+	client := NewUploadClient()
+
+	if client.Timeout != 0 {
+		t.Errorf("Expected no timeout, got %v", client.Timeout)
+	}
+
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatal("Expected client to have http.Transport")
+	}
+
+	if transport.ResponseHeaderTimeout != UploadResponseTimeout {
+		t.Errorf("Expected ResponseHeaderTimeout to be %v, got %v", UploadResponseTimeout, transport.ResponseHeaderTimeout)
+	}
+}
+
+// TestDoFormRequest verifies that params are sent as a form body for POST and as the query otherwise
+func TestDoFormRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != `MaaS token="token"` {
+			t.Errorf("Expected MaaS token header, got %q", got)
+		}
+		if r.Method == "POST" {
+			if got := r.Header.Get("Content-Type"); got != "application/x-www-form-urlencoded" {
+				t.Errorf("Expected form content type, got %q", got)
+			}
+			if r.URL.RawQuery != "" {
+				t.Errorf("Expected no query for POST, got %q", r.URL.RawQuery)
+			}
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Unexpected error parsing form: %v", err)
+		}
+		w.Write([]byte(r.Method + " " + r.Form.Get("name")))
+	}))
+	defer server.Close()
+
+	params := url.Values{"name": {"a&b"}}
+	for _, method := range []string{"GET", "POST"} {
+		body, err := DoFormRequest(method, server.URL+"/endpoint", params, "token")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if want := method + " a&b"; string(body) != want {
+			t.Errorf("Expected %q, got %q", want, string(body))
+		}
+	}
+}
@@ -0,0 +1,21 @@
+// Package paging collects every item of a paged MaaS360 listing.
+package paging
+
+import "fmt"
+
+// All calls fetch for page 1, 2, ... and returns the items of every page. fetch returns one page
+// of items and the total number of items reported by MaaS360, or 0 if it reports none. Paging
+// stops at the first page shorter than pageSize or once count items have been collected.
+func All[T any](pageSize int, fetch func(pageNumber int) ([]T, int, error)) ([]T, error) {
+	var all []T
+	for pageNumber := 1; ; pageNumber++ {
+		page, count, err := fetch(pageNumber)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching page %d: %v", pageNumber, err)
+		}
+		all = append(all, page...)
+		if len(page) < pageSize || (count > 0 && len(all) >= count) {
+			return all, nil
+		}
+	}
+}
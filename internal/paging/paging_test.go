@@ -0,0 +1,55 @@
+package paging
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		count     int // Count reported with each page, 0 for none
+		wantPages int
+	}{
+		{name: "short last page", total: 5, wantPages: 3},
+		{name: "exact multiple without count", total: 6, wantPages: 4},
+		{name: "exact multiple with count", total: 6, count: 6, wantPages: 3},
+		{name: "empty", total: 0, wantPages: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages := 0
+			all, err := All(2, func(pageNumber int) ([]int, int, error) {
+				pages++
+				var page []int
+				for i := (pageNumber - 1) * 2; i < pageNumber*2 && i < tt.total; i++ {
+					page = append(page, i)
+				}
+				return page, tt.count, nil
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(all) != tt.total {
+				t.Errorf("Expected %d items, got %d", tt.total, len(all))
+			}
+			if pages != tt.wantPages {
+				t.Errorf("Expected %d pages fetched, got %d", tt.wantPages, pages)
+			}
+		})
+	}
+}
+
+func TestAll_Error(t *testing.T) {
+	_, err := All(2, func(pageNumber int) ([]int, int, error) {
+		if pageNumber == 2 {
+			return nil, 0, fmt.Errorf("timeout")
+		}
+		return []int{1, 2}, 0, nil
+	})
+	if err == nil || err.Error() != "error fetching page 2: timeout" {
+		t.Errorf("Expected page 2 error, got %v", err)
+	}
+}
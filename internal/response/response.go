@@ -0,0 +1,44 @@
+// Package response decodes the status responses MaaS360 returns for change requests.
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Status is the outcome MaaS360 reports for a change request.
+type Status struct {
+	Status      string `json:"status"`
+	Description string `json:"description"`
+}
+
+// Err returns an error if the status is set and is not "Success".
+func (s Status) Err() error {
+	if s.Status != "" && !strings.EqualFold(s.Status, "Success") {
+		return fmt.Errorf("request failed with status %s: %s", s.Status, s.Description)
+	}
+	return nil
+}
+
+// Decode parses a change response, which MaaS360 returns either bare or wrapped in an object
+// named wrapper, for example "response" or "actionResponse". T usually embeds Status; a status
+// other than "Success" is reported as an error.
+func Decode[T interface{ Err() error }](body []byte, wrapper string) (T, error) {
+	var result T
+	var wrapped map[string]json.RawMessage
+	if err := json.Unmarshal(body, &wrapped); err != nil {
+		return result, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+	data := body
+	if inner, ok := wrapped[wrapper]; ok && string(inner) != "null" {
+		data = inner
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+	if err := result.Err(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
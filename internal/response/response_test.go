@@ -0,0 +1,37 @@
+package response
+
+import "testing"
+
+type appResult struct {
+	Status
+	AppID string `json:"appId"`
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantID  string
+		wantErr bool
+	}{
+		{name: "wrapped", body: `{"actionResponse":{"appId":"com.example","status":"Success"}}`, wantID: "com.example"},
+		{name: "bare", body: `{"appId":"com.example","status":"SUCCESS"}`, wantID: "com.example"},
+		{name: "no status", body: `{"appId":"com.example"}`, wantID: "com.example"},
+		{name: "null wrapper", body: `{"actionResponse":null,"appId":"com.example"}`, wantID: "com.example"},
+		{name: "failed", body: `{"actionResponse":{"status":"Failure","description":"App not found"}}`, wantErr: true},
+		{name: "invalid JSON", body: `not json`, wantErr: true},
+		{name: "array", body: `[]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Decode[appResult]([]byte(tt.body), "actionResponse")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if !tt.wantErr && result.AppID != tt.wantID {
+				t.Errorf("Expected app ID %q, got %q", tt.wantID, result.AppID)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package policies
+
+import (
+	"fmt"
+	"net/url"
+
+	"maas360api/devices"
+	"maas360api/internal/response"
+)
+
+// PublishResult is the outcome of publishing a policy.
+type PublishResult struct {
+	PolicyName  string
+	Status      string // Status returned by MaaS360
+	Description string // Description returned by MaaS360
+}
+
+// PublishPolicy publishes the pending changes of a policy to the devices it is assigned to.
+func PublishPolicy(serviceURL string, billingID string, policyType PolicyType, policyName string, maasToken string) (*PublishResult, error) {
+	if !policyType.Valid() {
+		return nil, fmt.Errorf("unknown policy type %q, expected one of %v", policyType, PolicyTypes)
+	}
+	if policyName == "" {
+		return nil, fmt.Errorf("policyName must not be empty")
+	}
+	params := url.Values{}
+	params.Set("policyType", string(policyType))
+	params.Set("policyName", policyName)
+	body, err := doPolicyRequest("POST", serviceURL, billingID, "publishPolicy", params, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("error publishing policy %s: %v", policyName, err)
+	}
+	result, err := response.Decode[response.Status](body, "response")
+	if err != nil {
+		return nil, fmt.Errorf("error publishing policy %s: %v", policyName, err)
+	}
+	return &PublishResult{PolicyName: policyName, Status: result.Status, Description: result.Description}, nil
+}
+
+// AssignDevicePolicy assigns a policy to a device, after checking that the policy exists.
+// Persona policies replace the device's persona policy; every other type replaces its MDM policy.
+func AssignDevicePolicy(serviceURL string, billingID string, deviceID string, policyType PolicyType, policyName string, maasToken string) (*devices.ActionResult, error) {
+	policy, err := GetPolicy(serviceURL, billingID, policyType, policyName, maasToken)
+	if err != nil {
+		return nil, err
+	}
+	if policyType == Persona {
+		return devices.ChangePersonaPolicy(serviceURL, billingID, deviceID, policy.Name, maasToken)
+	}
+	return devices.ChangeMDMPolicy(serviceURL, billingID, deviceID, policy.Name, maasToken)
+}
@@ -0,0 +1,133 @@
+// Package policies provides access to MaaS360 MDM and persona policies.
+package policies
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	httputil "maas360api/internal/http"
+	"maas360api/types"
+)
+
+// PolicyType is the kind of a MaaS360 policy, which determines the platform it applies to.
+type PolicyType string
+
+const (
+	IOSMDM     PolicyType = "iOS MDM"
+	AndroidMDM PolicyType = "Android MDM"
+	WindowsMDM PolicyType = "Windows Phone MDM"
+	MacMDM     PolicyType = "OS X MDM"
+	Persona    PolicyType = "Persona Policy"
+)
+
+// PolicyTypes lists the policy types accepted by MaaS360.
+var PolicyTypes = []PolicyType{IOSMDM, AndroidMDM, WindowsMDM, MacMDM, Persona}
+
+// Valid reports whether t is one of the policy types accepted by MaaS360.
+func (t PolicyType) Valid() bool {
+	for _, policyType := range PolicyTypes {
+		if t == policyType {
+			return true
+		}
+	}
+	return false
+}
+
+// MDMPolicyType returns the MDM policy type for a device platform name, as found in
+// devices.Device.Platform.
+func MDMPolicyType(platform string) (PolicyType, error) {
+	switch strings.ToLower(platform) {
+	case "ios":
+		return IOSMDM, nil
+	case "android":
+		return AndroidMDM, nil
+	case "windows", "windows phone":
+		return WindowsMDM, nil
+	case "mac", "os x", "macos":
+		return MacMDM, nil
+	default:
+		return "", fmt.Errorf("no MDM policy type for platform %q", platform)
+	}
+}
+
+// Policy describes a MaaS360 policy.
+type Policy struct {
+	Name              string               `json:"policyName"`
+	Type              PolicyType           `json:"policyType"`
+	Status            string               `json:"policyStatus"`
+	Version           types.FlexibleString `json:"policyVersion"`
+	Description       string               `json:"description"`
+	IsDefault         types.FlexibleBool   `json:"isDefault"`
+	LastModifiedDate  types.Date           `json:"lastModifiedDate"`
+	LastPublishedDate types.Date           `json:"lastPublishedDate"`
+}
+
+type policyOrPolicies []Policy
+
+func (p *policyOrPolicies) UnmarshalJSON(data []byte) error {
+	// Try as array
+	var arr []Policy
+	if err := json.Unmarshal(data, &arr); err == nil {
+		*p = arr
+		return nil
+	}
+	// Try as single object
+	var single Policy
+	if err := json.Unmarshal(data, &single); err == nil {
+		*p = []Policy{single}
+		return nil
+	}
+	return fmt.Errorf("policyOrPolicies: cannot unmarshal %s", string(data))
+}
+
+type policiesResponse struct {
+	Policies struct {
+		Policy policyOrPolicies `json:"policy"`
+	} `json:"policies"`
+}
+
+// ListPolicies retrieves the policies of a policy type.
+func ListPolicies(serviceURL string, billingID string, policyType PolicyType, maasToken string) ([]Policy, error) {
+	if !policyType.Valid() {
+		return nil, fmt.Errorf("unknown policy type %q, expected one of %v", policyType, PolicyTypes)
+	}
+	params := url.Values{}
+	params.Set("policyType", string(policyType))
+	body, err := doPolicyRequest("GET", serviceURL, billingID, "getPolicies", params, maasToken)
+	if err != nil {
+		return nil, err
+	}
+	var response policiesResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+	return response.Policies.Policy, nil
+}
+
+// GetPolicy retrieves the metadata of a policy by name, compared case-insensitively.
+func GetPolicy(serviceURL string, billingID string, policyType PolicyType, policyName string, maasToken string) (*Policy, error) {
+	if policyName == "" {
+		return nil, fmt.Errorf("policyName must not be empty")
+	}
+	policies, err := ListPolicies(serviceURL, billingID, policyType, maasToken)
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range policies {
+		if strings.EqualFold(policy.Name, policyName) {
+			return &policy, nil
+		}
+	}
+	return nil, fmt.Errorf("%s policy %s not found", policyType, policyName)
+}
+
+// doPolicyRequest sends a request to a policymgmt-apis endpoint and returns the response body.
+// Params are sent as a form body for POST requests and as the query string otherwise.
+func doPolicyRequest(method string, serviceURL string, billingID string, endpoint string, params url.Values, maasToken string) ([]byte, error) {
+	if serviceURL == "" || billingID == "" || maasToken == "" {
+		return nil, fmt.Errorf("serviceURL, billingID, and maasToken must not be empty")
+	}
+	return httputil.DoFormRequest(method, fmt.Sprintf("%s/policymgmt-apis/policies/2.0/%s/customer/%s", serviceURL, endpoint, billingID), params, maasToken)
+}
@@ -0,0 +1,76 @@
+package policies
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newPolicyServer(t *testing.T, assigned *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/policymgmt-apis/policies/2.0/getPolicies/customer/1234":
+			if got := r.URL.Query().Get("policyType"); got != string(IOSMDM) {
+				t.Errorf("Expected policyType %s, got %q", IOSMDM, got)
+			}
+			w.Write([]byte(`{"policies":{"policy":[
+				{"policyName":"Default iOS","policyType":"iOS MDM","isDefault":"Yes","policyVersion":3},
+				{"policyName":"Kiosk","policyType":"iOS MDM","isDefault":false,"lastPublishedDate":"2024-03-01"}
+			]}}`))
+		case "/device-apis/devices/1.0/changeDevicePolicy/1234":
+			if err := r.ParseForm(); err != nil {
+				t.Fatalf("Unexpected error parsing form: %v", err)
+			}
+			*assigned = r.PostForm.Get("policyName")
+			w.Write([]byte(`{"actionResponse":{"maas360DeviceID":"ApplABC","actionStatus":0,"actionID":"9"}}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestGetPolicy(t *testing.T) {
+	server := newPolicyServer(t, new(string))
+	defer server.Close()
+
+	policy, err := GetPolicy(server.URL, "1234", IOSMDM, "default ios", "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if policy.Name != "Default iOS" || !policy.IsDefault.Value || policy.Version.String() != "3" {
+		t.Errorf("Unexpected policy: %+v", policy)
+	}
+
+	if _, err := GetPolicy(server.URL, "1234", IOSMDM, "Missing", "token"); err == nil {
+		t.Error("Expected error for unknown policy")
+	}
+	if _, err := ListPolicies(server.URL, "1234", PolicyType("iOS"), "token"); err == nil {
+		t.Error("Expected error for unknown policy type")
+	}
+}
+
+func TestAssignDevicePolicy(t *testing.T) {
+	var assigned string
+	server := newPolicyServer(t, &assigned)
+	defer server.Close()
+
+	result, err := AssignDevicePolicy(server.URL, "1234", "ApplABC", IOSMDM, "kiosk", "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if assigned != "Kiosk" {
+		t.Errorf("Expected policy Kiosk to be assigned, got %q", assigned)
+	}
+	if result.ActionID != "9" {
+		t.Errorf("Expected action ID 9, got %s", result.ActionID)
+	}
+}
+
+func TestMDMPolicyType(t *testing.T) {
+	if got, err := MDMPolicyType("Android"); err != nil || got != AndroidMDM {
+		t.Errorf("Expected %s, got %s (%v)", AndroidMDM, got, err)
+	}
+	if _, err := MDMPolicyType("Others"); err == nil {
+		t.Error("Expected error for unknown platform")
+	}
+}
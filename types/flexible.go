@@ -0,0 +1,219 @@
+// Package types provides JSON types for MaaS360 fields whose encoding varies between
+// endpoints, such as IDs sent as strings or numbers and booleans sent as "Yes" or 1.
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// FlexibleInt represents a field that can be either a string or an integer in JSON
+type FlexibleInt struct {
+	Value int64
+	IsSet bool
+}
+
+// UnmarshalJSON implements json.Unmarshaler for FlexibleInt
+func (f *FlexibleInt) UnmarshalJSON(data []byte) error {
+	f.IsSet = true
+
+	// Try as integer first
+	var intVal int64
+	if err := json.Unmarshal(data, &intVal); err == nil {
+		f.Value = intVal
+		return nil
+	}
+
+	// Try as string
+	var strVal string
+	if err := json.Unmarshal(data, &strVal); err == nil {
+		if strVal == "" {
+			f.IsSet = false
+			f.Value = 0
+			return nil
+		}
+
+		intVal, err := strconv.ParseInt(strVal, 10, 64)
+		if err != nil {
+			f.IsSet = false
+			f.Value = 0
+			return nil
+		}
+
+		f.Value = intVal
+		return nil
+	}
+
+	f.IsSet = false
+	f.Value = 0
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for FlexibleInt
+func (f FlexibleInt) MarshalJSON() ([]byte, error) {
+	if !f.IsSet {
+		return json.Marshal("")
+	}
+	return json.Marshal(f.Value)
+}
+
+// Int64 returns the integer value, 0 if not set
+func (f FlexibleInt) Int64() int64 {
+	if !f.IsSet {
+		return 0
+	}
+	return f.Value
+}
+
+// String returns the string representation
+func (f FlexibleInt) String() string {
+	if !f.IsSet {
+		return ""
+	}
+	return strconv.FormatInt(f.Value, 10)
+}
+
+// FlexibleString represents a field that can be a string, number or boolean in JSON.
+// Numbers keep their literal text, so large identifiers are not rounded.
+type FlexibleString struct {
+	Value string
+	IsSet bool
+}
+
+// UnmarshalJSON implements json.Unmarshaler for FlexibleString
+func (f *FlexibleString) UnmarshalJSON(data []byte) error {
+	f.IsSet = false
+	f.Value = ""
+
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil
+	}
+	switch data[0] {
+	case '"':
+		var strVal string
+		if err := json.Unmarshal(data, &strVal); err != nil {
+			return nil
+		}
+		f.Value = strVal
+		f.IsSet = strVal != ""
+	case '{', '[', 'n':
+		// Objects, arrays and null have no string value
+	default:
+		// Numbers and booleans
+		f.Value = string(data)
+		f.IsSet = true
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for FlexibleString
+func (f FlexibleString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Value)
+}
+
+// String returns the string value, empty if not set
+func (f FlexibleString) String() string {
+	return f.Value
+}
+
+// FlexibleBool represents a field that can be a boolean, a string such as "Yes", "true" or "1",
+// or a number in JSON
+type FlexibleBool struct {
+	Value bool
+	IsSet bool
+}
+
+// ParseFlexibleBool interprets the boolean spellings used by MaaS360.
+func ParseFlexibleBool(s string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "yes", "y", "1", "enabled", "on":
+		return true, true
+	case "false", "no", "n", "0", "disabled", "off":
+		return false, true
+	}
+	return false, false
+}
+
+// UnmarshalJSON implements json.Unmarshaler for FlexibleBool
+func (f *FlexibleBool) UnmarshalJSON(data []byte) error {
+	var s FlexibleString
+	if err := s.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	f.Value, f.IsSet = ParseFlexibleBool(s.Value)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for FlexibleBool
+func (f FlexibleBool) MarshalJSON() ([]byte, error) {
+	if !f.IsSet {
+		return json.Marshal("")
+	}
+	return json.Marshal(f.Value)
+}
+
+// Bool returns the boolean value, false if not set
+func (f FlexibleBool) Bool() bool {
+	return f.IsSet && f.Value
+}
+
+// String returns the string representation
+func (f FlexibleBool) String() string {
+	if !f.IsSet {
+		return ""
+	}
+	return strconv.FormatBool(f.Value)
+}
+
+// FlexibleFloat represents a field that can be either a string or a number in JSON
+type FlexibleFloat struct {
+	Value float64
+	IsSet bool
+}
+
+// UnmarshalJSON implements json.Unmarshaler for FlexibleFloat
+func (f *FlexibleFloat) UnmarshalJSON(data []byte) error {
+	var s FlexibleString
+	if err := s.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	f.IsSet = false
+	f.Value = 0
+	if !s.IsSet {
+		return nil
+	}
+	floatVal, err := strconv.ParseFloat(strings.TrimSpace(s.Value), 64)
+	if err != nil {
+		return nil
+	}
+	f.Value = floatVal
+	f.IsSet = true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for FlexibleFloat
+func (f FlexibleFloat) MarshalJSON() ([]byte, error) {
+	if !f.IsSet {
+		return json.Marshal("")
+	}
+	return json.Marshal(f.Value)
+}
+
+// Float64 returns the float value, 0 if not set
+func (f FlexibleFloat) Float64() float64 {
+	if !f.IsSet {
+		return 0
+	}
+	return f.Value
+}
+
+// String returns the string representation
+func (f FlexibleFloat) String() string {
+	if !f.IsSet {
+		return ""
+	}
+	return strconv.FormatFloat(f.Value, 'f', -1, 64)
+}
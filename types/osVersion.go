@@ -0,0 +1,149 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// OSVersion represents an operating system version that MaaS360 reports as a string or a number.
+// The original text is kept, so 17.10 is not collapsed to 17.1, and the numeric components are
+// parsed for comparison. It understands versions such as iOS and macOS "17.1.2", Android "14"
+// and Windows "10.0.19045.3693"; anything after the numeric components, such as an iOS build
+// in parentheses, is kept in Extra.
+type OSVersion struct {
+	Raw   string // Version as reported
+	Major int
+	Minor int
+	Patch int
+	Build int
+	Extra string // Text following the numeric components
+	valid bool
+}
+
+// ParseOSVersion parses a version string. It returns an error if s has no numeric components.
+func ParseOSVersion(s string) (OSVersion, error) {
+	v := OSVersion{Raw: s}
+	text := strings.TrimSpace(s)
+	start := strings.IndexAny(text, "0123456789")
+	if start < 0 {
+		return v, fmt.Errorf("invalid OS version %q", s)
+	}
+	text = text[start:]
+
+	components := []*int{&v.Major, &v.Minor, &v.Patch, &v.Build}
+	for i, component := range components {
+		end := 0
+		for end < len(text) && text[end] >= '0' && text[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			break
+		}
+		n, err := strconv.Atoi(text[:end])
+		if err != nil {
+			return v, fmt.Errorf("invalid OS version %q: %v", s, err)
+		}
+		*component = n
+		text = text[end:]
+		if i == len(components)-1 || len(text) < 2 || text[0] != '.' || text[1] < '0' || text[1] > '9' {
+			break
+		}
+		text = text[1:]
+	}
+	v.Extra = strings.TrimSpace(text)
+	v.valid = true
+	return v, nil
+}
+
+// MustParseOSVersion is like ParseOSVersion but panics if s cannot be parsed.
+func MustParseOSVersion(s string) OSVersion {
+	v, err := ParseOSVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// UnmarshalJSON implements json.Unmarshaler for OSVersion
+func (v *OSVersion) UnmarshalJSON(data []byte) error {
+	*v = OSVersion{}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+
+	// Strings are used as-is; numbers keep their literal text
+	raw := string(data)
+	if data[0] == '"' {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil
+		}
+	}
+	if raw == "" {
+		return nil
+	}
+
+	parsed, err := ParseOSVersion(raw)
+	if err != nil {
+		v.Raw = raw
+		return nil
+	}
+	*v = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for OSVersion
+func (v OSVersion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Raw)
+}
+
+// IsSet reports whether the version was reported and could be parsed.
+func (v OSVersion) IsSet() bool {
+	return v.valid
+}
+
+// String returns the version as reported
+func (v OSVersion) String() string {
+	return v.Raw
+}
+
+// Compare compares the numeric components of two versions, returning -1, 0 or 1.
+// Missing components count as zero, so "17.4" equals "17.4.0". Versions that are not set
+// sort before all others.
+func (v OSVersion) Compare(other OSVersion) int {
+	if v.valid != other.valid {
+		if v.valid {
+			return 1
+		}
+		return -1
+	}
+	a := [4]int{v.Major, v.Minor, v.Patch, v.Build}
+	b := [4]int{other.Major, other.Minor, other.Patch, other.Build}
+	for i := range a {
+		if a[i] < b[i] {
+			return -1
+		}
+		if a[i] > b[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Less reports whether v is an earlier version than other.
+func (v OSVersion) Less(other OSVersion) bool {
+	return v.Compare(other) < 0
+}
+
+// Below reports whether v is set and earlier than the version s, for example Below("17.4").
+// It returns false if s cannot be parsed.
+func (v OSVersion) Below(s string) bool {
+	other, err := ParseOSVersion(s)
+	if err != nil || !v.valid {
+		return false
+	}
+	return v.Less(other)
+}
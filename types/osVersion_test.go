@@ -0,0 +1,111 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseOSVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected [4]int
+		extra    string
+		wantErr  bool
+	}{
+		{name: "iOS", input: "17.1.2", expected: [4]int{17, 1, 2, 0}},
+		{name: "iOS with build", input: "17.1.2 (21B101)", expected: [4]int{17, 1, 2, 0}, extra: "(21B101)"},
+		{name: "Android major only", input: "14", expected: [4]int{14, 0, 0, 0}},
+		{name: "Windows", input: "10.0.19045.3693", expected: [4]int{10, 0, 19045, 3693}},
+		{name: "macOS", input: "14.2.1", expected: [4]int{14, 2, 1, 0}},
+		{name: "prefixed", input: "Windows 11", expected: [4]int{11, 0, 0, 0}},
+		{name: "two digit minor", input: "17.10", expected: [4]int{17, 10, 0, 0}},
+		{name: "no digits", input: "Unknown", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := ParseOSVersion(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Expected error, got %+v", v)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			got := [4]int{v.Major, v.Minor, v.Patch, v.Build}
+			if got != tt.expected {
+				t.Errorf("Expected components %v, got %v", tt.expected, got)
+			}
+			if v.Extra != tt.extra {
+				t.Errorf("Expected extra %q, got %q", tt.extra, v.Extra)
+			}
+			if v.String() != tt.input {
+				t.Errorf("Expected String() to return %q, got %q", tt.input, v.String())
+			}
+		})
+	}
+}
+
+func TestOSVersion_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		raw      string
+		expected bool
+	}{
+		{name: "string", input: `"17.1"`, raw: "17.1", expected: true},
+		{name: "float keeps trailing zero", input: `17.10`, raw: "17.10", expected: true},
+		{name: "integer", input: `14`, raw: "14", expected: true},
+		{name: "empty string", input: `""`, raw: "", expected: false},
+		{name: "null", input: `null`, raw: "", expected: false},
+		{name: "unparseable", input: `"Unknown"`, raw: "Unknown", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v OSVersion
+			if err := json.Unmarshal([]byte(tt.input), &v); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if v.Raw != tt.raw {
+				t.Errorf("Expected raw %q, got %q", tt.raw, v.Raw)
+			}
+			if v.IsSet() != tt.expected {
+				t.Errorf("Expected IsSet %v, got %v", tt.expected, v.IsSet())
+			}
+		})
+	}
+}
+
+func TestOSVersion_Compare(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"17.1", "17.4", -1},
+		{"17.10", "17.4", 1},
+		{"17.4", "17.4.0", 0},
+		{"17.4.1", "17.4", 1},
+		{"10.0.19045.3693", "10.0.22621.2428", -1},
+		{"14", "13.9.9", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			got := MustParseOSVersion(tt.a).Compare(MustParseOSVersion(tt.b))
+			if got != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+
+	if !MustParseOSVersion("17.3.1").Below("17.4") {
+		t.Error("Expected 17.3.1 to be below 17.4")
+	}
+	if (OSVersion{}).Below("17.4") {
+		t.Error("Expected unset version not to be below 17.4")
+	}
+}
@@ -0,0 +1,122 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayouts are the date formats seen in MaaS360 responses, tried in order.
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"01/02/2006 15:04:05",
+	"01/02/2006",
+	"2006/01/02",
+	"Jan 2, 2006",
+	"02-Jan-2006",
+	time.RFC1123,
+}
+
+// ParseDate parses a date in any of the formats MaaS360 uses. Times without a zone are read as UTC.
+func ParseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date %q", s)
+}
+
+// EpochMillis represents a timestamp in milliseconds since the Unix epoch that can be either a string or an integer in JSON
+type EpochMillis struct {
+	Value int64
+	IsSet bool
+}
+
+// UnmarshalJSON implements json.Unmarshaler for EpochMillis
+func (e *EpochMillis) UnmarshalJSON(data []byte) error {
+	var f FlexibleInt
+	if err := f.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	e.Value = f.Value
+	e.IsSet = f.IsSet
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for EpochMillis
+func (e EpochMillis) MarshalJSON() ([]byte, error) {
+	return FlexibleInt{Value: e.Value, IsSet: e.IsSet}.MarshalJSON()
+}
+
+// Time returns the timestamp in UTC. It returns the zero time if the value is not set or is 0,
+// which MaaS360 uses for events that have not happened.
+func (e EpochMillis) Time() time.Time {
+	if !e.IsSet || e.Value == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(e.Value).UTC()
+}
+
+// Int64 returns the number of milliseconds, 0 if not set
+func (e EpochMillis) Int64() int64 {
+	if !e.IsSet {
+		return 0
+	}
+	return e.Value
+}
+
+// String returns the string representation
+func (e EpochMillis) String() string {
+	if !e.IsSet {
+		return ""
+	}
+	return strconv.FormatInt(e.Value, 10)
+}
+
+// Date represents a date string in one of several formats. Unrecognized dates keep their
+// original text in Raw and leave Value as the zero time.
+type Date struct {
+	Value time.Time
+	Raw   string
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Date
+func (d *Date) UnmarshalJSON(data []byte) error {
+	*d = Date{}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil
+	}
+	d.Raw = s
+	if t, err := ParseDate(s); err == nil {
+		d.Value = t
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for Date
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Raw)
+}
+
+// IsSet reports whether the date was recognized
+func (d Date) IsSet() bool {
+	return !d.Value.IsZero()
+}
+
+// Time returns the parsed date, or the zero time if it was not recognized
+func (d Date) Time() time.Time {
+	return d.Value
+}
+
+// String returns the date as reported
+func (d Date) String() string {
+	return d.Raw
+}
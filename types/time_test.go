@@ -0,0 +1,67 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestEpochMillis_UnmarshalJSON(t *testing.T) {
+	expected := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Time
+	}{
+		{name: "integer", input: `1709296200000`, expected: expected},
+		{name: "string", input: `"1709296200000"`, expected: expected},
+		{name: "empty string", input: `""`, expected: time.Time{}},
+		{name: "zero", input: `0`, expected: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e EpochMillis
+			if err := json.Unmarshal([]byte(tt.input), &e); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !e.Time().Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, e.Time())
+			}
+		})
+	}
+}
+
+func TestDate_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Time
+	}{
+		{name: "ISO date", input: `"2025-06-30"`, expected: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)},
+		{name: "ISO date time", input: `"2025-06-30T08:15:00"`, expected: time.Date(2025, 6, 30, 8, 15, 0, 0, time.UTC)},
+		{name: "US date", input: `"06/30/2025"`, expected: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)},
+		{name: "month name", input: `"Jun 30, 2025"`, expected: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)},
+		{name: "empty", input: `""`, expected: time.Time{}},
+		{name: "unrecognized", input: `"someday"`, expected: time.Time{}},
+		{name: "not a string", input: `12`, expected: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Date
+			if err := json.Unmarshal([]byte(tt.input), &d); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !d.Time().Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, d.Time())
+			}
+		})
+	}
+
+	var d Date
+	json.Unmarshal([]byte(`"someday"`), &d)
+	if d.String() != "someday" || d.IsSet() {
+		t.Errorf("Expected unrecognized date to keep raw text and be unset, got %+v", d)
+	}
+}
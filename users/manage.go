@@ -0,0 +1,137 @@
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// LocalUser describes a local (non-directory) user to add.
+type LocalUser struct {
+	UserName    string // Required
+	Domain      string // Required
+	Email       string // Required
+	FullName    string
+	PhoneNumber string
+	Location    string
+}
+
+// UserUpdate holds new values for a user. Nil fields are left unchanged.
+type UserUpdate struct {
+	Email       *string
+	FullName    *string
+	PhoneNumber *string
+	Location    *string
+}
+
+// GetUser retrieves a user by its MaaS360 user identifier.
+func GetUser(serviceURL string, billingID string, userIdentifier string, maasToken string) (*User, error) {
+	if userIdentifier == "" {
+		return nil, fmt.Errorf("userIdentifier must not be empty")
+	}
+	params := url.Values{}
+	params.Set("userIdentifier", userIdentifier)
+	body, err := doUserRequest("GET", serviceURL, billingID, "getUser", params, maasToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var wrapped struct {
+		User *User `json:"user"`
+	}
+	if err := json.Unmarshal(body, &wrapped); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+	if wrapped.User != nil {
+		return wrapped.User, nil
+	}
+	var user User
+	if err := json.Unmarshal(body, &user); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+	if !user.UserIdentifier.IsSet {
+		return nil, fmt.Errorf("user %s not found", userIdentifier)
+	}
+	return &user, nil
+}
+
+// AddLocalUser adds a local user.
+func AddLocalUser(serviceURL string, billingID string, user LocalUser, maasToken string) (*Result, error) {
+	if user.UserName == "" || user.Domain == "" || user.Email == "" {
+		return nil, fmt.Errorf("userName, domain, and email must not be empty")
+	}
+	params := url.Values{}
+	params.Set("userName", user.UserName)
+	params.Set("domain", user.Domain)
+	params.Set("emailAddress", user.Email)
+	setIfNotEmpty(params, "fullName", user.FullName)
+	setIfNotEmpty(params, "phoneNumber", user.PhoneNumber)
+	setIfNotEmpty(params, "location", user.Location)
+
+	body, err := doUserRequest("POST", serviceURL, billingID, "addUser", params, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("error adding user %s: %v", user.UserName, err)
+	}
+	result, err := decodeResult(body)
+	if err != nil {
+		return nil, fmt.Errorf("error adding user %s: %v", user.UserName, err)
+	}
+	return result, nil
+}
+
+// UpdateUser changes the fields of a user that are set in the update.
+func UpdateUser(serviceURL string, billingID string, userIdentifier string, update UserUpdate, maasToken string) (*Result, error) {
+	if userIdentifier == "" {
+		return nil, fmt.Errorf("userIdentifier must not be empty")
+	}
+	params := url.Values{}
+	params.Set("userIdentifier", userIdentifier)
+	setIfNotNil(params, "emailAddress", update.Email)
+	setIfNotNil(params, "fullName", update.FullName)
+	setIfNotNil(params, "phoneNumber", update.PhoneNumber)
+	setIfNotNil(params, "location", update.Location)
+	if len(params) == 1 {
+		return nil, fmt.Errorf("no user fields to update")
+	}
+
+	body, err := doUserRequest("POST", serviceURL, billingID, "updateUser", params, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("error updating user %s: %v", userIdentifier, err)
+	}
+	result, err := decodeResult(body)
+	if err != nil {
+		return nil, fmt.Errorf("error updating user %s: %v", userIdentifier, err)
+	}
+	return result, nil
+}
+
+// DeleteUser deletes a user.
+func DeleteUser(serviceURL string, billingID string, userIdentifier string, maasToken string) (*Result, error) {
+	if userIdentifier == "" {
+		return nil, fmt.Errorf("userIdentifier must not be empty")
+	}
+	params := url.Values{}
+	params.Set("userIdentifier", userIdentifier)
+
+	body, err := doUserRequest("POST", serviceURL, billingID, "deleteUser", params, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("error deleting user %s: %v", userIdentifier, err)
+	}
+	result, err := decodeResult(body)
+	if err != nil {
+		return nil, fmt.Errorf("error deleting user %s: %v", userIdentifier, err)
+	}
+	return result, nil
+}
+
+func setIfNotEmpty(params url.Values, key string, value string) {
+	if value != "" {
+		params.Set(key, value)
+	}
+}
+
+func setIfNotNil(params url.Values, key string, value *string) {
+	if value != nil {
+		params.Set(key, *value)
+	}
+}
@@ -0,0 +1,77 @@
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"maas360api/internal/paging"
+)
+
+// MaxSearchPageSize is the largest page size accepted by the user search API.
+const MaxSearchPageSize = 250
+
+type users struct {
+	Count      int         `json:"count"`
+	PageNumber int         `json:"pageNumber"`
+	PageSize   int         `json:"pageSize"`
+	User       UserOrUsers `json:"user"`
+}
+
+type searchResponse struct {
+	Users users `json:"users"`
+}
+
+// SearchUsers returns one page of users matching the filters.
+func SearchUsers(serviceURL string, billingID string, filters map[string]string, maasToken string) ([]User, error) {
+	// Possible search filters:
+	// "partialUserName": "",
+	// "partialFullName": "",
+	// "email": "",
+	// "domain": "",
+	// "source": "", // ["0" (local), "1" (directory)]
+	// "includeAllUsers": "", // ["0" (users with devices), "1" (all users)] Default is "0"
+	// "pageSize": "25", // [25, 50, 100, 200, 250] Default is 50
+	// "pageNumber": "1",
+	params := url.Values{}
+	for key, value := range filters {
+		params.Set(key, value)
+	}
+	page, err := searchUsersPage(serviceURL, billingID, params, maasToken)
+	if err != nil {
+		return nil, err
+	}
+	return page.User, nil
+}
+
+// SearchAllUsers pages through the user search API and returns every user matching the filters.
+// Any pageSize or pageNumber in filters is ignored.
+func SearchAllUsers(serviceURL string, billingID string, filters map[string]string, maasToken string) ([]User, error) {
+	params := url.Values{}
+	for key, value := range filters {
+		params.Set(key, value)
+	}
+	params.Set("pageSize", strconv.Itoa(MaxSearchPageSize))
+
+	return paging.All(MaxSearchPageSize, func(pageNumber int) ([]User, int, error) {
+		params.Set("pageNumber", strconv.Itoa(pageNumber))
+		page, err := searchUsersPage(serviceURL, billingID, params, maasToken)
+		if err != nil {
+			return nil, 0, err
+		}
+		return page.User, page.Count, nil
+	})
+}
+
+func searchUsersPage(serviceURL string, billingID string, params url.Values, maasToken string) (*users, error) {
+	body, err := doUserRequest("GET", serviceURL, billingID, "search", params, maasToken)
+	if err != nil {
+		return nil, err
+	}
+	var response searchResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response JSON: %v; body: %s", err, string(body))
+	}
+	return &response.Users, nil
+}
@@ -0,0 +1,64 @@
+package users
+
+import (
+	"fmt"
+	"strings"
+
+	"maas360api/devices"
+)
+
+// GetUserDevices returns the devices of a user, matched on username and domain.
+func GetUserDevices(serviceURL string, billingID string, user User, maasToken string) ([]devices.Device, error) {
+	if user.UserName == "" {
+		return nil, fmt.Errorf("user has no userName")
+	}
+	filters := map[string]string{"partialUsername": user.UserName}
+	if user.Domain != "" {
+		filters["userDomain"] = user.Domain
+	}
+	found, err := devices.SearchAllDevices(serviceURL, billingID, filters, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("error searching devices of user %s: %v", user.UserName, err)
+	}
+
+	// The search matches partial usernames, so keep exact matches only
+	owned := make([]devices.Device, 0, len(found))
+	for _, device := range found {
+		if strings.EqualFold(device.Username, user.UserName) && (user.Domain == "" || strings.EqualFold(device.UserDomain, user.Domain)) {
+			owned = append(owned, device)
+		}
+	}
+	return owned, nil
+}
+
+// UserForDevice returns the full user record of a device's user, matched on username and domain,
+// or on email address if the device has no username.
+func UserForDevice(serviceURL string, billingID string, device devices.Device, maasToken string) (*User, error) {
+	filters := map[string]string{"includeAllUsers": "1"}
+	switch {
+	case device.Username != "":
+		filters["partialUserName"] = device.Username
+		if device.UserDomain != "" {
+			filters["domain"] = device.UserDomain
+		}
+	case device.Email != "":
+		filters["email"] = device.Email
+	default:
+		return nil, fmt.Errorf("device %s has no username or email address", device.ID)
+	}
+
+	found, err := SearchAllUsers(serviceURL, billingID, filters, maasToken)
+	if err != nil {
+		return nil, fmt.Errorf("error searching user of device %s: %v", device.ID, err)
+	}
+	for _, user := range found {
+		if device.Username != "" {
+			if strings.EqualFold(user.UserName, device.Username) && (device.UserDomain == "" || strings.EqualFold(user.Domain, device.UserDomain)) {
+				return &user, nil
+			}
+		} else if strings.EqualFold(user.Email, device.Email) {
+			return &user, nil
+		}
+	}
+	return nil, fmt.Errorf("no user found for device %s", device.ID)
+}
@@ -0,0 +1,83 @@
+// Package users provides access to the MaaS360 user APIs.
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	httputil "maas360api/internal/http"
+	"maas360api/internal/response"
+	"maas360api/types"
+)
+
+// User represents a MaaS360 user.
+type User struct {
+	UserIdentifier types.FlexibleString `json:"userIdentifier"`
+	UserName       string               `json:"userName"`
+	Domain         string               `json:"domain"`
+	Email          string               `json:"emailAddress"`
+	FullName       string               `json:"fullName"`
+	PhoneNumber    string               `json:"phoneNumber"`
+	Location       string               `json:"location"`
+	Source         string               `json:"source"`
+	AuthType       string               `json:"authType"`
+	Status         string               `json:"status"`
+	CreateDate     types.Date           `json:"createDate"`
+	UpdateDate     types.Date           `json:"updateDate"`
+}
+
+// UserOrUsers decodes a user list that MaaS360 returns as a single object when it has one entry.
+type UserOrUsers []User
+
+func (u *UserOrUsers) UnmarshalJSON(data []byte) error {
+	// Try as array
+	var arr []User
+	if err := json.Unmarshal(data, &arr); err == nil {
+		*u = arr
+		return nil
+	}
+	// Try as single object
+	var single User
+	if err := json.Unmarshal(data, &single); err == nil {
+		*u = []User{single}
+		return nil
+	}
+	return fmt.Errorf("UserOrUsers: cannot unmarshal %s", string(data))
+}
+
+// Result is the outcome of a user change accepted by MaaS360.
+type Result struct {
+	UserIdentifier string // Identifier of the user that was changed
+	Status         string // Status returned by MaaS360
+	Description    string // Description returned by MaaS360
+}
+
+type resultBody struct {
+	response.Status
+	UserIdentifier types.FlexibleString `json:"userIdentifier"`
+	ErrorCode      types.FlexibleInt    `json:"errorCode"`
+}
+
+// decodeResult parses a user change response, which MaaS360 returns either bare or wrapped in a
+// "response" object. A status other than "Success" is reported as an error.
+func decodeResult(body []byte) (*Result, error) {
+	result, err := response.Decode[resultBody](body, "response")
+	if err != nil {
+		return nil, err
+	}
+	return &Result{
+		UserIdentifier: result.UserIdentifier.String(),
+		Status:         result.Status.Status,
+		Description:    result.Description,
+	}, nil
+}
+
+// doUserRequest sends a request to a user-apis endpoint and returns the response body.
+// Params are sent as a form body for POST requests and as the query string otherwise.
+func doUserRequest(method string, serviceURL string, billingID string, endpoint string, params url.Values, maasToken string) ([]byte, error) {
+	if serviceURL == "" || billingID == "" || maasToken == "" {
+		return nil, fmt.Errorf("serviceURL, billingID, and maasToken must not be empty")
+	}
+	return httputil.DoFormRequest(method, fmt.Sprintf("%s/user-apis/user/1.0/%s/%s", serviceURL, endpoint, billingID), params, maasToken)
+}
@@ -0,0 +1,108 @@
+package users
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"maas360api/devices"
+)
+
+func TestDecodeResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantID  string
+		wantErr bool
+	}{
+		{name: "wrapped", body: `{"response":{"status":"Success","userIdentifier":"A1B2"}}`, wantID: "A1B2"},
+		{name: "bare", body: `{"status":"success","userIdentifier":1234}`, wantID: "1234"},
+		{name: "failure", body: `{"response":{"status":"Failed","description":"User exists"}}`, wantErr: true},
+		{name: "invalid JSON", body: `not json`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := decodeResult([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if err == nil && result.UserIdentifier != tt.wantID {
+				t.Errorf("Expected user identifier %s, got %s", tt.wantID, result.UserIdentifier)
+			}
+		})
+	}
+}
+
+func TestSearchAllUsers_Paginates(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/user-apis/user/1.0/search/1234" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		pageNumber, _ := strconv.Atoi(r.URL.Query().Get("pageNumber"))
+		if pageNumber == 1 {
+			body := `{"users":{"count":251,"pageNumber":1,"pageSize":250,"user":[`
+			for i := 0; i < MaxSearchPageSize; i++ {
+				if i > 0 {
+					body += ","
+				}
+				body += `{"userName":"user` + strconv.Itoa(i) + `"}`
+			}
+			w.Write([]byte(body + `]}}`))
+			return
+		}
+		w.Write([]byte(`{"users":{"count":251,"pageNumber":2,"pageSize":250,"user":{"userName":"last"}}}`))
+	}))
+	defer server.Close()
+
+	found, err := SearchAllUsers(server.URL, "1234", nil, "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(found) != 251 || found[250].UserName != "last" {
+		t.Errorf("Expected 251 users ending with last, got %d", len(found))
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+}
+
+func TestUserForDevice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("partialUserName"); got != "jdoe" {
+			t.Errorf("Expected partialUserName jdoe, got %q", got)
+		}
+		w.Write([]byte(`{"users":{"count":2,"user":[
+			{"userIdentifier":"U1","userName":"jdoe2","domain":"corp"},
+			{"userIdentifier":"U2","userName":"JDoe","domain":"corp","emailAddress":"jdoe@example.com"}
+		]}}`))
+	}))
+	defer server.Close()
+
+	user, err := UserForDevice(server.URL, "1234", devices.Device{Username: "jdoe", UserDomain: "CORP"}, "token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if user.UserIdentifier.String() != "U2" {
+		t.Errorf("Expected user U2, got %s", user.UserIdentifier)
+	}
+
+	if _, err := UserForDevice(server.URL, "1234", devices.Device{}, "token"); err == nil {
+		t.Error("Expected error for device without username or email")
+	}
+}
+
+func TestManageUsers_Validation(t *testing.T) {
+	if _, err := AddLocalUser("http://unused", "1234", LocalUser{UserName: "jdoe"}, "token"); err == nil {
+		t.Error("Expected error for missing domain and email")
+	}
+	if _, err := UpdateUser("http://unused", "1234", "U1", UserUpdate{}, "token"); err == nil {
+		t.Error("Expected error for empty update")
+	}
+	if _, err := DeleteUser("http://unused", "1234", "", "token"); err == nil {
+		t.Error("Expected error for empty user identifier")
+	}
+}